@@ -6,9 +6,12 @@ require (
 	github.com/golang/mock v1.6.0
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/manifoldco/promptui v0.9.0
+	github.com/mattn/go-runewidth v0.0.13
 	github.com/spf13/cobra v1.8.0
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/sys v0.0.0-20220610221304-9f5ed59c137d // indirect
+	golang.org/x/term v0.0.0-20220526004731-065cf7ba2467
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
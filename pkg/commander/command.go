@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/avocatl/admiral/pkg/display"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -23,6 +26,26 @@ func NewCols(vals ...string) Cols {
 	return vals
 }
 
+// Col describes a column of the column based
+// CLI displayer.
+//
+// Hidden columns are not part of the default set
+// of columns but can be requested using the fields
+// flag.
+type Col struct {
+	Name        string `display:"FIELD"`
+	Description string `display:"DESCRIPTION"`
+	Hidden      bool   `display:"HIDDEN"`
+}
+
+// FieldsAnnotation is the command annotation holding
+// the fields help section.
+const FieldsAnnotation = "admiral_fields"
+
+const fieldsUsageTemplate = `{{with index .Annotations "` + FieldsAnnotation + `"}}
+Available Fields:
+{{.}}{{end}}`
+
 // Config contains a command configuration.
 type Config struct {
 	DisableAutoGenTag     bool
@@ -49,6 +72,9 @@ type Config struct {
 	PostHookErr           func(cmd *cobra.Command, args []string) error
 	PreHookErr            func(cmd *cobra.Command, args []string) error
 	ValidArgsFunc         func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+	Produce               ProduceFunc
+	Style                 display.StyleFunc
+	Columns               []Col
 }
 
 // Supported flags.
@@ -58,17 +84,19 @@ const (
 	Int64Flag
 	Float64Flag
 	BoolFlag
+	DurationFlag
 )
 
 // FlagBindOptions exposes the parameters
 // used to bind a flag to a passed pointer.
 type FlagBindOptions struct {
-	Bound       bool
-	BindInt     *int
-	BindInt64   *int64
-	BindString  *string
-	BindBool    *bool
-	BindFloat64 *float64
+	Bound        bool
+	BindInt      *int
+	BindInt64    *int64
+	BindString   *string
+	BindBool     *bool
+	BindFloat64  *float64
+	BindDuration *time.Duration
 }
 
 // FlagConfig defines the configuration of a flag.
@@ -88,6 +116,7 @@ type FlagConfig struct {
 type Command struct {
 	*cobra.Command
 	cols     []string
+	fields   []Col
 	children []*Command
 }
 
@@ -106,6 +135,23 @@ func (c *Command) GetSubCommands() []*Command {
 }
 
 // Builder constructs a new command.
+//
+// When columns are provided the displayer flags are
+// added to the command, the columns described through
+// the config Columns are merged with the provided cols
+// and listed on the command help. The output file flags
+// are added to commands configured with Produce, with or
+// without columns.
+//
+// Commands configured with Produce instead of Execute
+// only retrieve their data, the displayable returned is
+// filtered, sorted, grouped and written to the command
+// output in the format requested through the flags, the
+// config Style customizes the table values when colors are
+// enabled through the color flag. Flags changing how rows
+// are retrieved or rendered, e.g. sort-by or watch, are
+// only added to these commands as Execute can not honor
+// them.
 func Builder(parent *Command, config Config, cols Cols) *Command {
 	cc := &cobra.Command{
 		Use:                config.Namespace,
@@ -136,21 +182,167 @@ func Builder(parent *Command, config Config, cols Cols) *Command {
 
 	c := &Command{Command: cc, cols: cols}
 
+	produces := config.Produce != nil && cc.Run == nil && cc.RunE == nil
+	if produces {
+		cc.RunE = produceAndDisplay(config.Produce, config.Style)
+	}
+
+	if len(config.Columns) > 0 {
+		c.fields = mergeCols(cols, config.Columns)
+		c.cols = visibleCols(c.fields)
+	}
+
 	if parent != nil {
 		parent.AddCommand(c)
 	}
 
 	if cols := c.cols; len(cols) > 0 {
 		addDisplayerFlags(c)
+		addFieldsHelp(c)
+
+		if produces {
+			addProduceFlags(c)
+		}
+	}
+
+	if produces {
+		addOutputFileFlags(c)
 	}
 
 	return c
 }
 
+// Fields returns the command columns along with their
+// descriptions.
+func (c *Command) Fields() []Col {
+	if c.fields != nil {
+		return c.fields
+	}
+
+	fields := make([]Col, 0, len(c.cols))
+	for _, col := range c.cols {
+		fields = append(fields, Col{Name: col})
+	}
+
+	return fields
+}
+
+func mergeCols(cols Cols, described []Col) []Col {
+	byName := make(map[string]Col, len(described))
+	for _, col := range described {
+		byName[col.Name] = col
+	}
+
+	fields := make([]Col, 0, len(cols)+len(described))
+	seen := make(map[string]bool, len(cols))
+
+	for _, name := range cols {
+		col, ok := byName[name]
+		if !ok {
+			col = Col{Name: name}
+		}
+
+		fields = append(fields, col)
+		seen[name] = true
+	}
+
+	for _, col := range described {
+		if !seen[col.Name] {
+			fields = append(fields, col)
+			seen[col.Name] = true
+		}
+	}
+
+	return fields
+}
+
+func visibleCols(fields []Col) Cols {
+	cols := NoCols()
+
+	for _, f := range fields {
+		if !f.Hidden {
+			cols = append(cols, f.Name)
+		}
+	}
+
+	return cols
+}
+
+func fieldNames(fields []Col) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
+
+func addFieldsHelp(c *Command) {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, 0, 0, 4, ' ', 0)
+	for _, f := range c.Fields() {
+		desc := f.Description
+		if f.Hidden {
+			desc = strings.TrimSpace(desc + " (hidden by default)")
+		}
+
+		fmt.Fprintf(w, "  %s\t%s\n", f.Name, desc)
+	}
+
+	_ = w.Flush()
+
+	// fields without a description are not padded.
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+
+	c.Annotations[FieldsAnnotation] = strings.Join(lines, "\n")
+
+	if tpl := c.UsageTemplate(); !strings.Contains(tpl, fieldsUsageTemplate) {
+		c.SetUsageTemplate(tpl + fieldsUsageTemplate)
+	}
+
+	run, runE := c.Run, c.RunE
+	if run == nil && runE == nil {
+		return
+	}
+
+	c.Run = nil
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if list, _ := cmd.Flags().GetBool("list-fields"); list {
+			return listFields(cmd, c.Fields())
+		}
+
+		if runE != nil {
+			return runE(cmd, args)
+		}
+
+		run(cmd, args)
+
+		return nil
+	}
+}
+
+func listFields(cmd *cobra.Command, fields []Col) error {
+	d, err := display.FromStructs(fields, display.StructOptions{})
+	if err != nil {
+		return err
+	}
+
+	return Display(cmd, display.DefaultDisplayer(cmd.OutOrStdout()), d, nil)
+}
+
 func addDisplayerFlags(c *Command) {
 	formatHelpText := fmt.Sprintf(
-		"select displayable fields to filter the console output, possible values are %s",
-		strings.Join(c.cols, ","),
+		"select displayable fields to filter the console output, possible values are %s "+
+			"(prefix a field with '+' or '-' to add it to or remove it from the default set)",
+		strings.Join(fieldNames(c.Fields()), ","),
 	)
 
 	AddFlag(
@@ -163,6 +355,40 @@ func addDisplayerFlags(c *Command) {
 		},
 	)
 
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "output",
+			Persistent: true,
+			Shorthand:  "o",
+			Usage: fmt.Sprintf(
+				"output format, possible values are %s (templates are passed after '=', e.g. jsonpath={.name})",
+				strings.Join(display.Formats(), ","),
+			),
+			Default: display.TableFormat,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:     "list-fields",
+			FlagType: BoolFlag,
+			Usage:    "List the available fields and exit",
+			Default:  false,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "color",
+			Persistent: true,
+			Usage:      "colorize the output, possible values are auto,always,never",
+			Default:    "auto",
+		},
+	)
+
 	AddFlag(
 		c,
 		FlagConfig{
@@ -175,6 +401,175 @@ func addDisplayerFlags(c *Command) {
 	)
 }
 
+// addProduceFlags adds the flags only honored by commands
+// configured with Produce, e.g. sorting or watching the rows.
+func addProduceFlags(c *Command) {
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "sort-by",
+			Persistent: true,
+			Usage:      "sort rows by a comma separated list of fields, prefix a field with '-' for descending order",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "filter",
+			Persistent: true,
+			Usage:      "only display rows matching the expression, e.g. status=active,age>30",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "wide",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Usage:      "Do not truncate values to fit the terminal width",
+			Default:    false,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "watch",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Shorthand:  "w",
+			Usage:      "Refresh the output on every interval until interrupted",
+			Default:    false,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "interval",
+			FlagType:   DurationFlag,
+			Persistent: true,
+			Usage:      "time between refreshes on watch mode",
+			Default:    display.DefaultWatchInterval,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "limit",
+			FlagType:   IntFlag,
+			Persistent: true,
+			Usage:      "maximum number of results per page",
+			Default:    0,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "page",
+			FlagType:   IntFlag,
+			Persistent: true,
+			Usage:      "page of results to retrieve",
+			Default:    0,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "cursor",
+			Persistent: true,
+			Usage:      "cursor of the page of results to retrieve",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "all",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Usage:      "Retrieve all the pages of results",
+			Default:    false,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "group-by",
+			Persistent: true,
+			Usage:      "comma separated list of fields to group the results by",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "agg",
+			Persistent: true,
+			Usage:      "comma separated list of aggregations (count, sum, avg, min, max) written as func:field, e.g. sum:amount",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "no-humanize",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Usage:      "Display exact values instead of humanized ones, e.g. timestamps instead of relative times",
+			Default:    false,
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "api-version",
+			Persistent: true,
+			Usage:      "wrap json and jsonl output in an envelope with the given api version",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "interactive",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Usage:      "Browse the results in an interactive table when running on a terminal",
+			Default:    false,
+		},
+	)
+}
+
+func addOutputFileFlags(c *Command) {
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "output-file",
+			Persistent: true,
+			Usage:      "write the output to a file, the format is inferred from its extension (.csv, .json, .yaml, .md, .html...) unless the output flag is set",
+		},
+	)
+
+	AddFlag(
+		c,
+		FlagConfig{
+			Name:       "append",
+			FlagType:   BoolFlag,
+			Persistent: true,
+			Usage:      "Append to the output file instead of replacing it (csv, tsv, jsonl or yaml)",
+			Default:    false,
+		},
+	)
+}
+
 // AddFlag attaches a flag of the given type with the
 // specified configuration.
 func AddFlag(cmd *Command, config FlagConfig) {
@@ -196,6 +591,8 @@ func AddFlag(cmd *Command, config FlagConfig) {
 		addFloat64Flag(flagger, &config)
 	case BoolFlag:
 		addBoolFlag(flagger, &config)
+	case DurationFlag:
+		addDurationFlag(flagger, &config)
 	default:
 		addStringFlag(flagger, &config)
 	}
@@ -248,6 +645,16 @@ func addBoolFlag(flagger *pflag.FlagSet, config *FlagConfig) {
 	}
 }
 
+func addDurationFlag(flagger *pflag.FlagSet, config *FlagConfig) {
+	val := config.Default.(time.Duration)
+
+	if config.Binding.Bound {
+		flagger.DurationVarP(config.Binding.BindDuration, config.Name, config.Shorthand, val, config.Usage)
+	} else {
+		flagger.DurationP(config.Name, config.Shorthand, val, config.Usage)
+	}
+}
+
 func addStringFlag(flagger *pflag.FlagSet, config *FlagConfig) {
 	if config.Default == nil {
 		config.Default = ""
@@ -1,8 +1,13 @@
 package commander
 
 import (
+	"bytes"
+	"context"
 	"testing"
+	"time"
 
+	"github.com/avocatl/admiral/pkg/display"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -256,6 +261,21 @@ func TestBuilder_ColsAndHeadersFlagAddition(t *testing.T) {
 			[]string{"name", "surname"},
 			"fields",
 		},
+		{
+			"test color flag is not nil when columns are provided",
+			[]string{"name", "surname"},
+			"color",
+		},
+		{
+			"test output flag is nil when no columns are provided",
+			[]string{},
+			"output",
+		},
+		{
+			"test output flag is not nil when columns are provided",
+			[]string{"name", "surname"},
+			"output",
+		},
 	}
 
 	for _, tt := range cases {
@@ -272,3 +292,206 @@ func TestBuilder_ColsAndHeadersFlagAddition(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_OutputFlagDefault(t *testing.T) {
+	cmd := Builder(nil, Config{Namespace: "test"}, []string{"name"})
+
+	flag := cmd.PersistentFlags().Lookup("output")
+
+	assert.Equal(t, "o", flag.Shorthand)
+	assert.Equal(t, "table", flag.DefValue)
+	assert.Contains(t, flag.Usage, "json")
+	assert.Contains(t, flag.Usage, "go-template")
+}
+
+func TestBuilder_Columns(t *testing.T) {
+	cmd := Builder(
+		nil,
+		Config{
+			Namespace: "test",
+			Columns: []Col{
+				{Name: "name", Description: "The user name"},
+				{Name: "email", Description: "The user email", Hidden: true},
+			},
+		},
+		[]string{"id", "name"},
+	)
+
+	assert.Equal(t, []string{"id", "name"}, cmd.cols)
+	assert.Equal(t, []Col{
+		{Name: "id"},
+		{Name: "name", Description: "The user name"},
+		{Name: "email", Description: "The user email", Hidden: true},
+	}, cmd.Fields())
+	assert.Contains(t, cmd.PersistentFlags().Lookup("fields").Usage, "id,name,email")
+}
+
+func TestBuilder_FieldsHelp(t *testing.T) {
+	cmd := Builder(
+		nil,
+		Config{
+			Namespace: "test",
+			Execute:   func(cmd *cobra.Command, args []string) {},
+			Columns: []Col{
+				{Name: "name", Description: "The user name"},
+				{Name: "email", Description: "The user email", Hidden: true},
+			},
+		},
+		[]string{"id"},
+	)
+
+	child := Builder(cmd, Config{Namespace: "child", Execute: func(cmd *cobra.Command, args []string) {}}, NoCols())
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Usage())
+	assert.Contains(t, b.String(), `
+Available Fields:
+  id
+  name     The user name
+  email    The user email (hidden by default)
+`)
+
+	b.Reset()
+	child.SetOut(b)
+
+	assert.Nil(t, child.Usage())
+	assert.NotContains(t, b.String(), "Available Fields")
+
+	// children without fields do not accept the list flag.
+	cmd.SetArgs([]string{"child", "--list-fields"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestBuilder_ListFields(t *testing.T) {
+	var executed bool
+
+	cmd := Builder(
+		nil,
+		Config{
+			Namespace: "test",
+			ExecuteErr: func(cmd *cobra.Command, args []string) error {
+				executed = true
+
+				return nil
+			},
+			Columns: []Col{
+				{Name: "name", Description: "The user name"},
+			},
+		},
+		[]string{"id"},
+	)
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--list-fields"})
+
+	assert.Nil(t, cmd.Execute())
+	assert.False(t, executed)
+	assert.Equal(t, `FIELD    DESCRIPTION      HIDDEN
+id                        false
+name     The user name    false
+`, b.String())
+
+	cmd.SetArgs([]string{"--list-fields=false"})
+
+	assert.Nil(t, cmd.Execute())
+	assert.True(t, executed)
+}
+
+func TestBuilder_ListFieldsKeepsExecute(t *testing.T) {
+	var executed bool
+
+	cmd := Builder(
+		nil,
+		Config{
+			Namespace: "test",
+			Execute: func(cmd *cobra.Command, args []string) {
+				executed = true
+			},
+		},
+		[]string{"id"},
+	)
+
+	cmd.SetArgs([]string{})
+
+	assert.Nil(t, cmd.Execute())
+	assert.True(t, executed)
+}
+
+func TestAddFlag_Duration(t *testing.T) {
+	cmd := Builder(nil, Config{Namespace: "test"}, []string{})
+
+	var d time.Duration
+	AddFlag(cmd, FlagConfig{
+		FlagType: DurationFlag,
+		Name:     "test-flag",
+		Default:  time.Minute,
+		Binding: FlagBindOptions{
+			Bound:        true,
+			BindDuration: &d,
+		},
+	})
+
+	flag, err := cmd.Flags().GetDuration("test-flag")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, flag)
+	assert.Equal(t, d, flag)
+}
+
+func TestBuilder_ProduceFlags(t *testing.T) {
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return display.Text("", ""), nil
+	}
+
+	flags := []string{
+		"sort-by", "filter", "wide", "watch", "interval", "limit", "page", "cursor", "all",
+		"group-by", "agg", "no-humanize", "api-version", "interactive", "output-file", "append",
+	}
+
+	execute := Builder(nil, Config{Namespace: "test", Execute: func(cmd *cobra.Command, args []string) {}}, []string{"name"})
+	produces := Builder(nil, Config{Namespace: "test", Produce: produce}, []string{"name"})
+
+	for _, name := range flags {
+		assert.Nil(t, execute.PersistentFlags().Lookup(name), name)
+		assert.NotNil(t, produces.PersistentFlags().Lookup(name), name)
+	}
+
+	// the flags of the commands displaying on their own are kept.
+	for _, name := range []string{"fields", "output", "color", "no-headers"} {
+		assert.NotNil(t, execute.PersistentFlags().Lookup(name), name)
+	}
+
+	assert.NotNil(t, execute.Flags().Lookup("list-fields"))
+}
+
+func TestBuilder_WatchFlags(t *testing.T) {
+	cmd := Builder(nil, Config{Namespace: "test", Produce: produceInvoices}, []string{"name"})
+
+	watch := cmd.PersistentFlags().Lookup("watch")
+	assert.Equal(t, "w", watch.Shorthand)
+
+	interval, err := cmd.PersistentFlags().GetDuration("interval")
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Second, interval)
+}
+
+func TestDisplay_CommandContext(t *testing.T) {
+	d, _ := display.FromStructs([]Col{{Name: "id"}}, display.StructOptions{})
+
+	cmd := &cobra.Command{}
+	b := bytes.NewBufferString("")
+
+	assert.Nil(t, Display(cmd, display.CSVDisplayer(b), d, []string{"FIELD"}))
+	assert.Equal(t, "FIELD\nid\n", b.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cmd.SetContext(ctx)
+	b.Reset()
+
+	assert.ErrorIs(t, Display(cmd, display.CSVDisplayer(b), d, nil), context.Canceled)
+	assert.Equal(t, "", b.String())
+}
@@ -0,0 +1,461 @@
+package commander
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/avocatl/admiral/pkg/display"
+	"github.com/spf13/cobra"
+)
+
+// Output flag errors.
+var (
+	// ErrWatchFormat is returned when watching an output other
+	// than the table.
+	ErrWatchFormat = errors.New("only the table output can be watched")
+	// ErrAPIVersionFormat is returned when requesting an api
+	// version for an output other than json or jsonl.
+	ErrAPIVersionFormat = errors.New("only the json and jsonl outputs can be versioned")
+)
+
+// machineFormats are the outputs meant for programs, which would
+// read summary footers as ordinary rows.
+var machineFormats = []string{
+	display.JSONFormat,
+	display.JSONLFormat,
+	display.YAMLFormat,
+	display.CSVFormat,
+	display.TSVFormat,
+	display.GoTemplateFormat,
+	display.JSONPathFormat,
+}
+
+// ProduceFunc retrieves the data displayed by a command.
+type ProduceFunc func(cmd *cobra.Command, args []string) (display.Displayable, error)
+
+// Display renders the displayable with the command context, so
+// rendering stops when the command is cancelled, e.g. on a
+// deadline set through cobra's ExecuteContext. Broken pipes end
+// the rendering without reporting an error.
+func Display(cmd *cobra.Command, dsp display.Displayer, d display.Displayable, fields []string) error {
+	return display.DisplayContext(commandContext(cmd), dsp, d, fields)
+}
+
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+// PageRequest returns the page requested through the paging
+// flags of the command.
+func PageRequest(cmd *cobra.Command) display.PageRequest {
+	return display.PageRequest{
+		Limit:  intFlag(cmd, "limit"),
+		Page:   intFlag(cmd, "page"),
+		Cursor: stringFlag(cmd, "cursor"),
+	}
+}
+
+// FetchPages retrieves the page requested through the paging
+// flags of the command, or every page when the all flag is set.
+func FetchPages(cmd *cobra.Command, fetch display.PageFetcher) (display.Displayable, error) {
+	if boolFlag(cmd, "all") {
+		return display.FetchAll(fetch, PageRequest(cmd))
+	}
+
+	return display.FetchPage(fetch, PageRequest(cmd))
+}
+
+// displayOptions holds the values of the displayer flags.
+type displayOptions struct {
+	output      string
+	fields      string
+	sortBy      string
+	filter      string
+	groupBy     string
+	agg         string
+	apiVersion  string
+	outputFile  string
+	color       string
+	interval    time.Duration
+	wide        bool
+	watch       bool
+	interactive bool
+	appendFile  bool
+	noHeaders   bool
+	noHumanize  bool
+}
+
+func readDisplayOptions(cmd *cobra.Command) displayOptions {
+	return displayOptions{
+		output:      stringFlag(cmd, "output"),
+		fields:      stringFlag(cmd, "fields"),
+		sortBy:      stringFlag(cmd, "sort-by"),
+		filter:      stringFlag(cmd, "filter"),
+		groupBy:     stringFlag(cmd, "group-by"),
+		agg:         stringFlag(cmd, "agg"),
+		apiVersion:  stringFlag(cmd, "api-version"),
+		color:       stringFlag(cmd, "color"),
+		interval:    durationFlag(cmd, "interval"),
+		wide:        boolFlag(cmd, "wide"),
+		watch:       boolFlag(cmd, "watch"),
+		interactive: boolFlag(cmd, "interactive"),
+		outputFile:  stringFlag(cmd, "output-file"),
+		appendFile:  boolFlag(cmd, "append"),
+		noHeaders:   boolFlag(cmd, "no-headers"),
+		noHumanize:  boolFlag(cmd, "no-humanize"),
+	}
+}
+
+// produceAndDisplay runs the producer and renders its result
+// following the displayer flags to the command output.
+func produceAndDisplay(produce ProduceFunc, style display.StyleFunc) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		opts := readDisplayOptions(cmd)
+
+		if err := opts.summarizable(cmd); err != nil {
+			return err
+		}
+
+		if err := opts.versionable(cmd); err != nil {
+			return err
+		}
+
+		color, err := display.ParseColorMode(opts.color)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+
+		// colors are decided for the command output, not the pager.
+		if color.Enabled(out) {
+			color = display.ColorAlways
+		} else {
+			color = display.ColorNever
+		}
+
+		table := display.TableConfig{
+			Width: display.TerminalWidth(out),
+			Wide:  opts.wide,
+			Style: style,
+			Color: color,
+			Exact: opts.noHumanize,
+		}
+
+		if opts.watch {
+			if err := opts.watchable(cmd); err != nil {
+				return err
+			}
+
+			// the first refresh is produced up front so invalid flags
+			// end the command instead of being redrawn.
+			d, err := produce(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			first, err := opts.apply(d)
+			if err != nil {
+				return err
+			}
+
+			return display.Watch(commandContext(cmd), out, func() (display.Displayable, error) {
+				if d := first; d != nil {
+					first = nil
+
+					return d, nil
+				}
+
+				d, err := produce(cmd, args)
+				if err != nil {
+					return nil, err
+				}
+
+				return opts.apply(d)
+			}, display.WatchConfig{
+				Interval: opts.interval,
+				Table:    table,
+			})
+		}
+
+		d, err := produce(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		view, err := opts.apply(d)
+		if err != nil {
+			return err
+		}
+
+		if opts.outputFile != "" {
+			// files are written as stdout would be, without colors
+			// or truncation.
+			config := display.FileConfig{
+				Append: opts.appendFile,
+				Displayer: func(format string, w io.Writer) (display.Displayer, error) {
+					return opts.displayer(format, w, display.TableConfig{Exact: opts.noHumanize})
+				},
+			}
+
+			// the format is inferred from the file unless requested.
+			if changedFlag(cmd, "output") {
+				config.Format = opts.output
+			}
+
+			dsp, err := display.FileDisplayer(opts.outputFile, config)
+			if err != nil {
+				return err
+			}
+
+			return Display(cmd, dsp, view, nil)
+		}
+
+		if opts.interactive {
+			// the requested format is used when not on a terminal.
+			fallback, err := opts.displayer(opts.output, out, table)
+			if err != nil {
+				return err
+			}
+
+			dsp := display.InteractiveDisplayer(out, display.InteractiveConfig{
+				Input:    cmd.InOrStdin(),
+				Exact:    opts.noHumanize,
+				Fallback: fallback,
+			})
+
+			return Display(cmd, dsp, view, nil)
+		}
+
+		pager := display.NewPager(out)
+
+		dsp, err := opts.displayer(opts.output, pager, table)
+		if err != nil {
+			return err
+		}
+
+		if err := Display(cmd, dsp, view, nil); err != nil {
+			return err
+		}
+
+		// readers leaving before the end are not an error.
+		if err := pager.Close(); err != nil && !display.IsBrokenPipe(err) {
+			return err
+		}
+
+		if p, ok := d.(display.Paginated); ok {
+			if hint := p.PageInfo().Hint(); hint != "" {
+				fmt.Fprintln(cmd.ErrOrStderr(), hint)
+			}
+		}
+
+		return nil
+	}
+}
+
+// watchable validates the outputs requested along with the watch
+// flag, only the table is refreshed in place.
+func (opts displayOptions) watchable(cmd *cobra.Command) error {
+	if changedFlag(cmd, "output") && !strings.EqualFold(opts.output, display.TableFormat) {
+		return fmt.Errorf("%w, got %s", ErrWatchFormat, opts.output)
+	}
+
+	if opts.outputFile != "" || opts.interactive {
+		return fmt.Errorf("%w, got an output file or the interactive viewer", ErrWatchFormat)
+	}
+
+	return nil
+}
+
+// summarizable rejects the summary footers, added by the agg flag
+// without group-by, on outputs meant for programs.
+func (opts displayOptions) summarizable(cmd *cobra.Command) error {
+	if opts.agg == "" || opts.groupBy != "" {
+		return nil
+	}
+
+	name := opts.format(cmd)
+
+	for _, f := range machineFormats {
+		if f == name {
+			return fmt.Errorf(
+				"%w: summary rows are not added to %s output, use --group-by to aggregate it",
+				display.ErrInvalidAggregation,
+				name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// versionable rejects api versions requested for outputs other
+// than json and jsonl, which have no envelope.
+func (opts displayOptions) versionable(cmd *cobra.Command) error {
+	if opts.apiVersion == "" {
+		return nil
+	}
+
+	if name := opts.format(cmd); name != display.JSONFormat && name != display.JSONLFormat {
+		return fmt.Errorf("%w, got %s", ErrAPIVersionFormat, name)
+	}
+
+	return nil
+}
+
+// format returns the name of the requested format, inferred from
+// the output file unless set through the output flag.
+func (opts displayOptions) format(cmd *cobra.Command) string {
+	format := opts.output
+	if opts.outputFile != "" && !changedFlag(cmd, "output") {
+		format, _ = display.FormatForFile(opts.outputFile)
+	}
+
+	name := strings.ToLower(format)
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// apply filters, sorts, groups and selects the fields of the
+// displayable as requested through the flags.
+func (opts displayOptions) apply(d display.Displayable) (display.Displayable, error) {
+	var err error
+
+	if opts.filter != "" {
+		filter, err := display.ParseFilter(opts.filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if d, err = display.Filtered(d, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.sortBy != "" {
+		if d, err = display.Sorted(d, display.ParseSortKeys(opts.sortBy)...); err != nil {
+			return nil, err
+		}
+	}
+
+	aggs, err := display.ParseAggregations(opts.agg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case opts.groupBy != "":
+		d, err = display.GroupBy(d, display.FilterColumns(opts.groupBy, nil), aggs...)
+	case len(aggs) > 0:
+		d, err = display.Summarized(d, aggs...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.fields == "" && !opts.noHeaders {
+		return d, nil
+	}
+
+	cols, err := display.ResolveFields(opts.fields, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &view{Displayable: d, cols: cols, noHeaders: opts.noHeaders || d.NoHeaders()}, nil
+}
+
+// displayer returns the displayer for the output format following
+// the displayer flags, for both the command output and files.
+func (opts displayOptions) displayer(output string, out io.Writer, table display.TableConfig) (display.Displayer, error) {
+	format := strings.ToLower(output)
+
+	if format == "" || format == display.TableFormat {
+		return display.TableDisplayer(out, table), nil
+	}
+
+	var (
+		dsp display.Displayer
+		err error
+	)
+
+	// versions of other formats are rejected by versionable.
+	switch {
+	case opts.apiVersion != "" && format == display.JSONFormat:
+		dsp = display.JSONRowsDisplayer(out, display.JSONConfig{APIVersion: opts.apiVersion})
+	case opts.apiVersion != "" && format == display.JSONLFormat:
+		dsp = display.JSONRowsDisplayer(out, display.JSONConfig{APIVersion: opts.apiVersion, Lines: true})
+	default:
+		dsp, err = display.NewDisplayer(output, out)
+	}
+
+	if err != nil || !opts.noHumanize {
+		return dsp, err
+	}
+
+	return display.ExactDisplayer(dsp), nil
+}
+
+// view is a displayable restricted to the selected fields.
+type view struct {
+	display.Displayable
+	cols      []string
+	noHeaders bool
+}
+
+// Cols returns the selected fields.
+func (v *view) Cols() []string {
+	return v.cols
+}
+
+// NoHeaders reports whether the headers are hidden.
+func (v *view) NoHeaders() bool {
+	return v.noHeaders
+}
+
+// Unwrap returns the displayable whose fields are selected.
+func (v *view) Unwrap() display.Displayable {
+	return v.Displayable
+}
+
+func changedFlag(cmd *cobra.Command, name string) bool {
+	f := cmd.Flags().Lookup(name)
+
+	return f != nil && f.Changed
+}
+
+func stringFlag(cmd *cobra.Command, name string) string {
+	v, _ := cmd.Flags().GetString(name)
+
+	return v
+}
+
+func intFlag(cmd *cobra.Command, name string) int {
+	v, _ := cmd.Flags().GetInt(name)
+
+	return v
+}
+
+func boolFlag(cmd *cobra.Command, name string) bool {
+	v, _ := cmd.Flags().GetBool(name)
+
+	return v
+}
+
+func durationFlag(cmd *cobra.Command, name string) time.Duration {
+	v, _ := cmd.Flags().GetDuration(name)
+
+	return v
+}
@@ -0,0 +1,412 @@
+package commander
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/avocatl/admiral/pkg/display"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type invoice struct {
+	ID     int    `display:"id,default"`
+	Status string `display:"status,default"`
+	Amount int    `display:"amount,default"`
+	Email  string `display:"email"`
+}
+
+var invoices = []invoice{
+	{ID: 1, Status: "paid", Amount: 10, Email: "a@example.com"},
+	{ID: 2, Status: "open", Amount: 25, Email: "b@example.com"},
+	{ID: 3, Status: "paid", Amount: 5, Email: "c@example.com"},
+}
+
+func produceInvoices(cmd *cobra.Command, args []string) (display.Displayable, error) {
+	return display.FromStructs(invoices, display.StructOptions{})
+}
+
+func executeProduce(t *testing.T, produce ProduceFunc, args ...string) (string, string, error) {
+	t.Helper()
+
+	cmd := Builder(nil, Config{Namespace: "invoices", Produce: produce}, []string{"id", "status", "amount"})
+
+	out, errOut := bytes.NewBufferString(""), bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), errOut.String(), err
+}
+
+func TestBuilder_Produce(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			"table by default",
+			nil,
+			"id    status    amount\n1     paid      10\n2     open      25\n3     paid      5\n",
+		},
+		{
+			"fields, filter and sort",
+			[]string{"-o", "csv", "-f", "+email,-status", "--filter", "amount>5", "--sort-by", "-amount"},
+			"id,amount,email\n2,25,b@example.com\n1,10,a@example.com\n",
+		},
+		{
+			"no headers",
+			[]string{"-o", "tsv", "--fields", "id", "--no-headers"},
+			"1\n2\n3\n",
+		},
+		{
+			"group by",
+			[]string{"-o", "csv", "--group-by", "status", "--agg", "count,sum:amount"},
+			"status,count,sum(amount)\npaid,2,15\nopen,1,25\n",
+		},
+		{
+			"summary footer",
+			[]string{"-o", "markdown", "--agg", "sum:amount"},
+			"| id | status | amount |\n| --- | --- | --- |\n| 1 | paid | 10 |\n| 2 | open | 25 |\n| 3 | paid | 5 |\n| SUM |  | 40 |\n",
+		},
+		{
+			"interactive falls back to the table",
+			[]string{"--interactive", "-f", "id"},
+			"id\n1\n2\n3\n",
+		},
+		{
+			"interactive falls back to the requested format",
+			[]string{"--interactive", "-f", "id", "-o", "jsonl"},
+			"{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n",
+		},
+		{
+			"json envelope",
+			[]string{"-o", "jsonl", "-f", "id", "--api-version", "v1"},
+			`{"apiVersion":"v1","item":{"id":1}}` + "\n" +
+				`{"apiVersion":"v1","item":{"id":2}}` + "\n" +
+				`{"apiVersion":"v1","item":{"id":3}}` + "\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, _, err := executeProduce(t, produceInvoices, c.args...)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, out)
+		})
+	}
+}
+
+func TestBuilder_ProduceRawJSON(t *testing.T) {
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return display.JSON(map[string]interface{}{"a": 1}, false), nil
+	}
+
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-o", "json", "--no-headers"}, "{\n    \"a\": 1\n}\n"},
+		{[]string{"-o", "yaml", "--no-headers"}, "a: 1\n"},
+		{[]string{"-o", "jsonpath={.a}", "--no-headers"}, "1\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.args[1], func(t *testing.T) {
+			out, _, err := executeProduce(t, produce, c.args...)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, out)
+		})
+	}
+}
+
+func TestBuilder_ProduceNoHumanize(t *testing.T) {
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return display.FromStructs([]struct {
+			Took time.Duration `display:"took"`
+		}{{90 * time.Minute}}, display.StructOptions{})
+	}
+
+	out, _, err := executeProduce(t, produce, "--no-humanize", "-f", "took")
+	assert.Nil(t, err)
+	assert.Equal(t, "took\n1h30m0s\n", out)
+
+	out, _, err = executeProduce(t, produce, "--no-humanize", "-o", "describe", "-f", "took")
+	assert.Nil(t, err)
+	assert.Equal(t, "took:  1h30m0s\n", out)
+
+	// the flag does not leak into later renders.
+	assert.True(t, display.Humanize())
+
+	out, _, err = executeProduce(t, produce, "-f", "took")
+	assert.Nil(t, err)
+	assert.Equal(t, "took\n1h30m\n", out)
+}
+
+// brokenPipe fails every write like a pipe whose reader exited.
+type brokenPipe struct{}
+
+func (brokenPipe) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("write |1: %w", syscall.EPIPE)
+}
+
+func TestBuilder_ProduceBrokenPipe(t *testing.T) {
+	for _, format := range []string{"table", "csv", "json"} {
+		cmd := Builder(nil, Config{Namespace: "invoices", Produce: produceInvoices}, []string{"id"})
+		cmd.SetOut(brokenPipe{})
+		cmd.SetArgs([]string{"-o", format})
+
+		assert.Nil(t, cmd.Execute(), format)
+	}
+}
+
+func TestBuilder_ProduceStyle(t *testing.T) {
+	cmd := Builder(nil, Config{
+		Namespace: "invoices",
+		Produce:   produceInvoices,
+		Style: func(col string, v interface{}) display.Style {
+			if v == "open" {
+				return display.Style{Color: display.Red}
+			}
+
+			return display.Style{}
+		},
+	}, []string{"id", "status"})
+
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--color", "always", "--filter", "status=open", "-f", "id,status"})
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, "id    status\n2     \x1b[31mopen\x1b[0m\n", out.String())
+
+	out.Reset()
+	cmd.SetArgs([]string{"--color", "never", "--filter", "status=open", "-f", "id,status"})
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, "id    status\n2     open\n", out.String())
+}
+
+func TestBuilder_ProduceWatchErrors(t *testing.T) {
+	cases := [][]string{
+		{"-w", "--fields", "nmae"},
+		{"-w", "--filter", "x>"},
+		{"-w", "-o", "json"},
+		{"-w", "--interactive"},
+	}
+
+	for _, args := range cases {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			cmd := Builder(nil, Config{Namespace: "invoices", Produce: produceInvoices}, []string{"id"})
+			cmd.SetOut(bytes.NewBufferString(""))
+			cmd.SetArgs(args)
+
+			assert.Error(t, cmd.ExecuteContext(ctx))
+			assert.Nil(t, ctx.Err(), "the command must fail before watching")
+		})
+	}
+}
+
+func TestBuilder_ProduceAPIVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoices.yaml")
+
+	cases := [][]string{
+		{"--api-version", "v1"},
+		{"--api-version", "v1", "-o", "yaml"},
+		{"--api-version", "v1", "-o", "csv"},
+		{"--api-version", "v1", "--output-file", path},
+	}
+
+	for _, args := range cases {
+		_, _, err := executeProduce(t, produceInvoices, args...)
+		assert.ErrorIs(t, err, ErrAPIVersionFormat, strings.Join(args, " "))
+	}
+
+	out, _, err := executeProduce(t, produceInvoices, "--api-version", "v1", "--no-humanize", "-o", "json", "-f", "id")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"apiVersion": "v1", "items": [{"id": 1}, {"id": 2}, {"id": 3}]}`, out)
+}
+
+func TestBuilder_ProduceWatch(t *testing.T) {
+	var calls int
+
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		calls++
+
+		return produceInvoices(cmd, args)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	cmd := Builder(nil, Config{Namespace: "invoices", Produce: produce}, []string{"id"})
+
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"-w", "-f", "id", "--interval", "1m"})
+
+	assert.Nil(t, cmd.ExecuteContext(ctx))
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, out.String(), "id\n1\n2\n3\n")
+}
+
+func TestBuilder_ProduceErrors(t *testing.T) {
+	_, _, err := executeProduce(t, produceInvoices, "--fields", "emial")
+	assert.ErrorIs(t, err, display.ErrUnknownField)
+
+	_, _, err = executeProduce(t, produceInvoices, "-o", "xml")
+	assert.ErrorIs(t, err, display.ErrUnknownFormat)
+
+	_, _, err = executeProduce(t, produceInvoices, "--agg", "median:amount")
+	assert.ErrorIs(t, err, display.ErrInvalidAggregation)
+
+	for _, format := range []string{"json", "jsonl", "yaml", "csv", "tsv"} {
+		_, _, err = executeProduce(t, produceInvoices, "--agg", "count", "-o", format)
+		assert.ErrorIs(t, err, display.ErrInvalidAggregation, format)
+	}
+
+	_, _, err = executeProduce(t, produceInvoices, "--agg", "count", "--output-file", "invoices.json")
+	assert.ErrorIs(t, err, display.ErrInvalidAggregation)
+
+	failure := errors.New("unavailable")
+
+	_, _, err = executeProduce(t, func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return nil, failure
+	})
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestBuilder_ProducePages(t *testing.T) {
+	var requests []display.PageRequest
+
+	fetch := func(req display.PageRequest) (display.Displayable, display.PageInfo, error) {
+		requests = append(requests, req)
+
+		d, err := display.FromStructs(invoices[req.Page-1:req.Page], display.StructOptions{})
+
+		return d, display.PageInfo{Page: req.Page, HasMore: req.Page < len(invoices)}, err
+	}
+
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return FetchPages(cmd, fetch)
+	}
+
+	out, errOut, err := executeProduce(t, produce, "-o", "csv", "--page", "2", "--limit", "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id,status,amount\n2,open,25\n", out)
+	assert.Equal(t, "more results available, use --page 3 or --all to retrieve them\n", errOut)
+	assert.Equal(t, []display.PageRequest{{Limit: 1, Page: 2}}, requests)
+
+	requests = nil
+
+	out, errOut, err = executeProduce(t, produce, "-o", "csv", "--page", "1", "--all")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id,status,amount\n1,paid,10\n2,open,25\n3,paid,5\n", out)
+	assert.Equal(t, "", errOut)
+	assert.Len(t, requests, 3)
+}
+
+func TestBuilder_ProduceKeepsExecute(t *testing.T) {
+	var executed bool
+
+	cmd := Builder(nil, Config{
+		Namespace: "test",
+		Execute:   func(cmd *cobra.Command, args []string) { executed = true },
+		Produce:   produceInvoices,
+	}, []string{"id"})
+
+	cmd.SetArgs(nil)
+
+	assert.Nil(t, cmd.Execute())
+	assert.True(t, executed)
+}
+
+func TestBuilder_ProduceOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invoices.md")
+
+	out, _, err := executeProduce(t, produceInvoices, "--output-file", path, "-f", "id,amount")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", out)
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "| id | amount |\n| --- | --- |\n| 1 | 10 |\n| 2 | 25 |\n| 3 | 5 |\n", string(content))
+
+	path = filepath.Join(dir, "invoices.data")
+
+	_, _, err = executeProduce(t, produceInvoices, "--output-file", path, "-o", "csv", "-f", "id")
+	assert.Nil(t, err)
+
+	_, _, err = executeProduce(t, produceInvoices, "--output-file", path, "-o", "csv", "-f", "id", "--append")
+	assert.Nil(t, err)
+
+	content, _ = ioutil.ReadFile(path)
+	assert.Equal(t, "id\n1\n2\n3\n1\n2\n3\n", string(content))
+}
+
+func TestBuilder_ProduceOutputFileFlags(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invoices.jsonl")
+
+	_, _, err := executeProduce(t, produceInvoices, "--output-file", path, "-f", "id", "--api-version", "v1")
+	assert.Nil(t, err)
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "{\"apiVersion\":\"v1\",\"item\":{\"id\":1}}\n"+
+		"{\"apiVersion\":\"v1\",\"item\":{\"id\":2}}\n"+
+		"{\"apiVersion\":\"v1\",\"item\":{\"id\":3}}\n", string(content))
+
+	produce := func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+		return display.FromStructs([]struct {
+			Took time.Duration `display:"took"`
+		}{{90 * time.Minute}}, display.StructOptions{})
+	}
+
+	path = filepath.Join(dir, "took.txt")
+
+	_, _, err = executeProduce(t, produce, "--output-file", path, "--no-humanize", "-f", "took")
+	assert.Nil(t, err)
+
+	content, _ = ioutil.ReadFile(path)
+	assert.Equal(t, "took\n1h30m0s\n", string(content))
+}
+
+func TestBuilder_ProduceOutputFileWithoutCols(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoice.json")
+
+	cmd := Builder(nil, Config{
+		Namespace: "invoice",
+		Produce: func(cmd *cobra.Command, args []string) (display.Displayable, error) {
+			return display.JSON(map[string]interface{}{"id": 1}, false), nil
+		},
+	}, nil)
+
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("append"))
+
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--output-file", path})
+
+	assert.Nil(t, cmd.Execute())
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "{\n    \"id\": 1\n}\n", string(content))
+}
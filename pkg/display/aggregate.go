@@ -0,0 +1,376 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidAggregation is returned when an aggregation can not
+// be parsed.
+var ErrInvalidAggregation = errors.New("invalid aggregation")
+
+// Aggregation functions.
+const (
+	AggCount = "count"
+	AggSum   = "sum"
+	AggAvg   = "avg"
+	AggMin   = "min"
+	AggMax   = "max"
+)
+
+var aggFuncs = []string{AggCount, AggSum, AggAvg, AggMin, AggMax}
+
+// Aggregation computes a value out of the values of a column.
+//
+// Count without a column counts rows, otherwise values that are
+// nil are ignored by every function.
+type Aggregation struct {
+	Func string
+	Col  string
+}
+
+// Name returns the column name used for the aggregation result,
+// e.g. "sum(amount)".
+func (a Aggregation) Name() string {
+	if a.Col == "" {
+		return a.Func
+	}
+
+	return a.Func + "(" + a.Col + ")"
+}
+
+// ParseAggregations parses a comma separated list of aggregations
+// written as func:column, e.g. "count,sum:amount,max:created".
+func ParseAggregations(req string) ([]Aggregation, error) {
+	var aggs []Aggregation
+
+	for _, a := range strings.Split(req, ",") {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+
+		fn, col := a, ""
+		if i := strings.Index(a, ":"); i >= 0 {
+			fn, col = strings.TrimSpace(a[:i]), strings.TrimSpace(a[i+1:])
+		}
+
+		fn = strings.ToLower(fn)
+
+		switch {
+		case !knownAggregation(fn):
+			return nil, fmt.Errorf(
+				"%w %q, possible functions are %s",
+				ErrInvalidAggregation,
+				a,
+				strings.Join(aggFuncs, ","),
+			)
+		case col == "" && fn != AggCount:
+			return nil, fmt.Errorf("%w %q, %s requires a column e.g. %s:amount", ErrInvalidAggregation, a, fn, fn)
+		}
+
+		aggs = append(aggs, Aggregation{Func: fn, Col: col})
+	}
+
+	return aggs, nil
+}
+
+func knownAggregation(fn string) bool {
+	for _, f := range aggFuncs {
+		if f == fn {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Aggregate computes the aggregation over the provided rows.
+//
+// Sums keep integer values when every summed value is an
+// integer, min and max return the original value, comparing
+// them the same way sorting does. Sums and averages skip the
+// values that are not numeric. Nil is returned when no value
+// could be aggregated.
+func (a Aggregation) Aggregate(rows []map[string]interface{}) interface{} {
+	if a.Col == "" {
+		return len(rows)
+	}
+
+	var (
+		count   int
+		summed  int
+		sum     float64
+		isum    int64
+		integer = true
+		found   interface{}
+	)
+
+	for _, r := range rows {
+		v := r[a.Col]
+		if v == nil {
+			continue
+		}
+
+		count++
+
+		switch a.Func {
+		case AggSum, AggAvg:
+			f, ok := numeric(v)
+			if !ok {
+				continue
+			}
+
+			summed++
+			sum += f
+
+			if i, ok := integerValue(v); ok && integer {
+				isum += i
+			} else {
+				integer = false
+			}
+		case AggMin:
+			if found == nil || compareValues(v, found) < 0 {
+				found = v
+			}
+		case AggMax:
+			if found == nil || compareValues(v, found) > 0 {
+				found = v
+			}
+		}
+	}
+
+	switch a.Func {
+	case AggCount:
+		return count
+	case AggSum:
+		if summed == 0 {
+			return nil
+		}
+
+		if integer {
+			return isum
+		}
+
+		return sum
+	case AggAvg:
+		if summed == 0 {
+			return nil
+		}
+
+		return sum / float64(summed)
+	default:
+		return found
+	}
+}
+
+func integerValue(v interface{}) (int64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+func checkAggregations(d Displayable, aggs []Aggregation) error {
+	for _, a := range aggs {
+		if a.Col == "" {
+			continue
+		}
+
+		if err := checkColumn(d, a.Col); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type summarizedDisplayable struct {
+	Displayable
+	aggs []Aggregation
+}
+
+// KV returns the rows of the wrapped displayable followed by a
+// footer row for every aggregation function.
+func (sd *summarizedDisplayable) KV() []map[string]interface{} {
+	rows := sd.Displayable.KV()
+	kv := append([]map[string]interface{}{}, rows...)
+
+	var (
+		footers = map[string]map[string]interface{}{}
+		order   []string
+	)
+
+	for _, a := range sd.aggs {
+		footer, ok := footers[a.Func]
+		if !ok {
+			footer = map[string]interface{}{}
+			footers[a.Func] = footer
+			order = append(order, a.Func)
+		}
+
+		col := a.Col
+		if col == "" {
+			col = sd.labelCol()
+		}
+
+		footer[col] = a.Aggregate(rows)
+	}
+
+	for _, fn := range order {
+		footer := footers[fn]
+
+		// footers are labeled on the first column when it is free.
+		if label := sd.labelCol(); label != "" {
+			if _, ok := footer[label]; !ok {
+				footer[label] = strings.ToUpper(fn)
+			}
+		}
+
+		kv = append(kv, footer)
+	}
+
+	return kv
+}
+
+func (sd *summarizedDisplayable) labelCol() string {
+	if cols := sd.Cols(); len(cols) > 0 {
+		return cols[0]
+	}
+
+	return ""
+}
+
+// Summarized wraps a displayable so its rows are followed by a
+// footer row for each aggregation function, e.g. the sum of
+// every summed column. Footer rows are labeled with the function
+// name on the first column when it is not aggregated itself, a
+// count without a column is written on the first column.
+//
+// Footers are ordinary rows to the displayers, so summaries are
+// meant for human readable layouts such as the table, machine
+// formats should use GroupBy instead.
+//
+// An error wrapping ErrUnknownColumn is returned when aggregating
+// a column that is not part of the displayable.
+func Summarized(d Displayable, aggs ...Aggregation) (Displayable, error) {
+	if err := checkAggregations(d, aggs); err != nil {
+		return nil, err
+	}
+
+	if len(aggs) == 0 {
+		return d, nil
+	}
+
+	return &summarizedDisplayable{Displayable: d, aggs: aggs}, nil
+}
+
+type groupedDisplayable struct {
+	Displayable
+	by   []string
+	aggs []Aggregation
+}
+
+// KV returns a row for each distinct combination of values on
+// the grouped columns, in order of appearance, holding the
+// aggregation results.
+func (gd *groupedDisplayable) KV() []map[string]interface{} {
+	var (
+		groups = map[string][]map[string]interface{}{}
+		order  []string
+	)
+
+	for _, r := range gd.Displayable.KV() {
+		values := make([]string, len(gd.by))
+		for i, col := range gd.by {
+			values[i] = fmt.Sprintf("%T:%v", r[col], r[col])
+		}
+
+		key := strings.Join(values, "\x00")
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+
+		groups[key] = append(groups[key], r)
+	}
+
+	kv := make([]map[string]interface{}, 0, len(order))
+
+	for _, key := range order {
+		rows := groups[key]
+		row := make(map[string]interface{}, len(gd.by)+len(gd.aggs))
+
+		for _, col := range gd.by {
+			row[col] = rows[0][col]
+		}
+
+		for _, a := range gd.aggs {
+			row[a.Name()] = a.Aggregate(rows)
+		}
+
+		kv = append(kv, row)
+	}
+
+	return kv
+}
+
+// Cols returns the grouped columns followed by the aggregations.
+func (gd *groupedDisplayable) Cols() []string {
+	cols := append([]string{}, gd.by...)
+
+	for _, a := range gd.aggs {
+		cols = append(cols, a.Name())
+	}
+
+	return cols
+}
+
+// ColMap describes the grouped columns and aggregations.
+func (gd *groupedDisplayable) ColMap() map[string]string {
+	desc := gd.Displayable.ColMap()
+	m := make(map[string]string, len(gd.by)+len(gd.aggs))
+
+	for _, col := range gd.by {
+		m[col] = desc[col]
+	}
+
+	for _, a := range gd.aggs {
+		m[a.Name()] = a.Name()
+	}
+
+	return m
+}
+
+// GroupBy returns a displayable with a row for each distinct
+// combination of values of the provided columns along with the
+// result of the aggregations over the rows of each group, e.g.
+// the count of rows by status. Rows are counted when no
+// aggregation is provided.
+//
+// An error wrapping ErrUnknownColumn is returned when grouping
+// or aggregating columns that are not part of the displayable.
+func GroupBy(d Displayable, by []string, aggs ...Aggregation) (Displayable, error) {
+	for _, col := range by {
+		if err := checkColumn(d, col); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkAggregations(d, aggs); err != nil {
+		return nil, err
+	}
+
+	if len(aggs) == 0 {
+		aggs = []Aggregation{{Func: AggCount}}
+	}
+
+	return &groupedDisplayable{Displayable: d, by: by, aggs: aggs}, nil
+}
@@ -0,0 +1,108 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var invoices = []map[string]interface{}{
+	{"id": 1, "status": "paid", "amount": 10, "fee": 0.5},
+	{"id": 2, "status": "open", "amount": 25, "fee": nil},
+	{"id": 3, "status": "paid", "amount": 5, "fee": 1.25},
+}
+
+func invoicesDisplayable() Displayable {
+	return &staticDisplayable{rows: invoices, cols: []string{"id", "status", "amount", "fee"}}
+}
+
+func TestParseAggregations(t *testing.T) {
+	aggs, err := ParseAggregations("count, SUM:amount,max:fee")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Aggregation{
+		{Func: AggCount},
+		{Func: AggSum, Col: "amount"},
+		{Func: AggMax, Col: "fee"},
+	}, aggs)
+	assert.Equal(t, "sum(amount)", aggs[1].Name())
+
+	_, err = ParseAggregations("median:amount")
+	assert.ErrorIs(t, err, ErrInvalidAggregation)
+
+	_, err = ParseAggregations("sum")
+	assert.ErrorIs(t, err, ErrInvalidAggregation)
+}
+
+func TestAggregation_Aggregate(t *testing.T) {
+	cases := []struct {
+		agg  Aggregation
+		want interface{}
+	}{
+		{Aggregation{Func: AggCount}, 3},
+		{Aggregation{Func: AggCount, Col: "fee"}, 2},
+		{Aggregation{Func: AggSum, Col: "amount"}, int64(40)},
+		{Aggregation{Func: AggSum, Col: "fee"}, 1.75},
+		{Aggregation{Func: AggAvg, Col: "fee"}, 0.875},
+		{Aggregation{Func: AggMin, Col: "amount"}, 5},
+		{Aggregation{Func: AggMax, Col: "status"}, "paid"},
+		{Aggregation{Func: AggMax, Col: "missing"}, nil},
+		{Aggregation{Func: AggSum, Col: "status"}, nil},
+		{Aggregation{Func: AggAvg, Col: "status"}, nil},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, c.agg.Aggregate(invoices), c.agg.Name())
+	}
+}
+
+func TestAggregation_AggregateSkipsNonNumeric(t *testing.T) {
+	rows := []map[string]interface{}{{"amount": 10}, {"amount": "n/a"}, {"amount": 20}}
+
+	assert.Equal(t, int64(30), Aggregation{Func: AggSum, Col: "amount"}.Aggregate(rows))
+	assert.Equal(t, 15.0, Aggregation{Func: AggAvg, Col: "amount"}.Aggregate(rows))
+	assert.Equal(t, 3, Aggregation{Func: AggCount, Col: "amount"}.Aggregate(rows))
+}
+
+func TestSummarized(t *testing.T) {
+	d, err := Summarized(
+		invoicesDisplayable(),
+		Aggregation{Func: AggSum, Col: "amount"},
+		Aggregation{Func: AggSum, Col: "fee"},
+		Aggregation{Func: AggMax, Col: "amount"},
+	)
+
+	assert.Nil(t, err)
+
+	kv := d.KV()
+	assert.Len(t, kv, 5)
+	assert.Equal(t, map[string]interface{}{"id": "SUM", "amount": int64(40), "fee": 1.75}, kv[3])
+	assert.Equal(t, map[string]interface{}{"id": "MAX", "amount": 25}, kv[4])
+
+	_, err = Summarized(invoicesDisplayable(), Aggregation{Func: AggSum, Col: "total"})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestGroupBy(t *testing.T) {
+	d, err := GroupBy(
+		invoicesDisplayable(),
+		[]string{"status"},
+		Aggregation{Func: AggCount},
+		Aggregation{Func: AggSum, Col: "amount"},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"status", "count", "sum(amount)"}, d.Cols())
+	assert.Equal(t, []map[string]interface{}{
+		{"status": "paid", "count": 2, "sum(amount)": int64(15)},
+		{"status": "open", "count": 1, "sum(amount)": int64(25)},
+	}, d.KV())
+
+	d, err = GroupBy(invoicesDisplayable(), []string{"status"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"status", "count"}, d.Cols())
+
+	_, err = GroupBy(invoicesDisplayable(), []string{"state"})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-
-	"github.com/avocatl/admiral/pkg/commander"
 )
 
 type jsonDisplayer struct {
@@ -39,7 +37,7 @@ func (jd *jsonDisplayer) KV() []map[string]interface{} {
 
 // Cols returns an array of columns available for displaying.
 func (jd *jsonDisplayer) Cols() []string {
-	return commander.NewCols("")
+	return []string{""}
 }
 
 // ColMap returns a list of columns and its description.
@@ -69,6 +67,29 @@ func JSON(d interface{}, pretty bool) Displayable {
 	}
 }
 
+// Wrapper is implemented by displayables decorating another one
+// without changing its data, e.g. sorting it or hiding headers,
+// so the formats can look through them for the data wrapped with
+// JSON.
+type Wrapper interface {
+	Unwrap() Displayable
+}
+
+// rawJSON returns the displayable created with JSON, if any,
+// looking through the wrappers decorating it.
+func rawJSON(d Displayable) (*jsonDisplayer, bool) {
+	for {
+		switch t := d.(type) {
+		case *jsonDisplayer:
+			return t, true
+		case Wrapper:
+			d = t.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
 type textDisplayable struct {
 	Divider string
 	Text    string
@@ -102,7 +123,7 @@ func (td *textDisplayable) KV() []map[string]interface{} {
 
 // Cols returns an array of columns available for displaying.
 func (td *textDisplayable) Cols() []string {
-	return commander.NewCols("")
+	return []string{""}
 }
 
 // ColMap returns a list of columns and its description.
@@ -0,0 +1,135 @@
+package display
+
+import (
+	"context"
+	"errors"
+	"io"
+	"syscall"
+)
+
+// ContextDisplayer is implemented by displayers able to stop
+// rendering once the context is done.
+type ContextDisplayer interface {
+	Displayer
+	DisplayContext(ctx context.Context, d Displayable, f []string) error
+	DisplayManyContext(ctx context.Context, ds []Displayable, f []string) error
+}
+
+// ContextStreamer is implemented by streamers able to stop
+// once the context is done.
+type ContextStreamer interface {
+	Streamer
+	StreamContext(ctx context.Context, s Streamable, f []string) error
+}
+
+// outputWrapper is implemented by the displayers that can be
+// copied writing to a wrapped output.
+type outputWrapper interface {
+	wrapOutput(wrap func(io.Writer) io.Writer) Displayer
+}
+
+// DisplayContext renders the displayable with the displayer,
+// using its context aware variant when implemented. Built-in
+// displayers write through a ContextWriter, stopping on their
+// next write once the context is done. Otherwise the context is
+// checked before rendering.
+//
+// Broken pipes, e.g. when the output is piped to head(1), end
+// the rendering without reporting an error.
+func DisplayContext(ctx context.Context, dsp Displayer, d Displayable, f []string) error {
+	return DisplayManyContext(ctx, dsp, []Displayable{d}, f)
+}
+
+// DisplayManyContext renders the displayables with the displayer,
+// using its context aware variant when implemented. Otherwise the
+// context is checked before rendering each displayable.
+//
+// Broken pipes end the rendering without reporting an error.
+func DisplayManyContext(ctx context.Context, dsp Displayer, ds []Displayable, f []string) error {
+	if cd, ok := dsp.(ContextDisplayer); ok {
+		return ignoreBrokenPipe(cd.DisplayManyContext(ctx, ds, f))
+	}
+
+	if ow, ok := dsp.(outputWrapper); ok {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		dsp = ow.wrapOutput(func(w io.Writer) io.Writer {
+			return ContextWriter(ctx, w)
+		})
+
+		return ignoreBrokenPipe(dsp.DisplayMany(ds, f))
+	}
+
+	for _, d := range ds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := dsp.Display(d, f); err != nil {
+			return ignoreBrokenPipe(err)
+		}
+	}
+
+	return nil
+}
+
+// StreamContext streams the streamable with the streamer, using
+// its context aware variant when implemented. Broken pipes end
+// the stream without reporting an error.
+func StreamContext(ctx context.Context, st Streamer, s Streamable, f []string) error {
+	if cs, ok := st.(ContextStreamer); ok {
+		return ignoreBrokenPipe(cs.StreamContext(ctx, s, f))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ignoreBrokenPipe(st.Stream(s, f))
+}
+
+// wrapDisplayerOutput returns a copy of the displayer writing to
+// the wrapped output, or the displayer itself when not supported.
+func wrapDisplayerOutput(dsp Displayer, wrap func(io.Writer) io.Writer) Displayer {
+	if ow, ok := dsp.(outputWrapper); ok {
+		return ow.wrapOutput(wrap)
+	}
+
+	return dsp
+}
+
+// IsBrokenPipe reports whether the error was caused by writing
+// to a pipe whose reader was closed.
+func IsBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE)
+}
+
+func ignoreBrokenPipe(err error) error {
+	if IsBrokenPipe(err) {
+		return nil
+	}
+
+	return err
+}
+
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return cw.w.Write(p)
+}
+
+// ContextWriter returns a writer failing with the context error
+// once it is done, so displayers writing to it stop on their
+// next write.
+func ContextWriter(ctx context.Context, w io.Writer) io.Writer {
+	return &contextWriter{ctx: ctx, w: stdout(w)}
+}
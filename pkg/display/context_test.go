@@ -0,0 +1,125 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// brokenPipe fails every write like a pipe whose reader exited.
+type brokenPipe struct{}
+
+func (brokenPipe) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("write |1: %w", syscall.EPIPE)
+}
+
+func TestDisplayContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := bytes.NewBufferString("")
+	d := &staticDisplayable{rows: currencies, cols: currencyCol}
+
+	err := DisplayContext(ctx, DefaultDisplayer(b), d, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = DisplayManyContext(ctx, CSVDisplayer(b), []Displayable{d}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = StreamContext(ctx, StreamDisplayer(b, StreamConfig{}), StreamOf(d), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, "", b.String())
+}
+
+func TestDisplayContext_BrokenPipe(t *testing.T) {
+	d := &staticDisplayable{rows: currencies, cols: currencyCol}
+
+	err := DefaultDisplayer(brokenPipe{}).Display(d, nil)
+	assert.True(t, IsBrokenPipe(err))
+
+	assert.Nil(t, DisplayContext(context.Background(), DefaultDisplayer(brokenPipe{}), d, nil))
+	assert.Nil(t, DisplayContext(context.Background(), JSONDisplayer(brokenPipe{}), d, nil))
+	assert.Nil(t, StreamContext(context.Background(), StreamDisplayer(brokenPipe{}, StreamConfig{}), StreamOf(d), nil))
+}
+
+// cancelWriter cancels the context after the first write.
+type cancelWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (cw *cancelWriter) Write(p []byte) (int, error) {
+	defer cw.cancel()
+
+	return cw.Buffer.Write(p)
+}
+
+func TestDisplayContext_StopsMidRender(t *testing.T) {
+	rows := make([]map[string]interface{}, 5000)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"id": i, "name": "row"}
+	}
+
+	d := &staticDisplayable{rows: rows, cols: []string{"id", "name"}}
+
+	for _, format := range []string{"csv", "jsonl", "markdown", "html", "describe", "tree", "go-template={{.id}}"} {
+		t.Run(format, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			w := &cancelWriter{cancel: cancel}
+
+			dsp, err := NewDisplayer(format, w)
+			assert.Nil(t, err)
+
+			err = DisplayContext(ctx, dsp, d, nil)
+
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.NotContains(t, w.String(), "4999")
+		})
+	}
+}
+
+func TestStreamContext_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	produced := 0
+	rows := FuncRows(func() (map[string]interface{}, error) {
+		produced++
+		if produced == 3 {
+			cancel()
+		}
+
+		return map[string]interface{}{"id": produced}, nil
+	})
+
+	b := bytes.NewBufferString("")
+
+	err := StreamContext(ctx, StreamDisplayer(b, StreamConfig{Sample: 1}), &eventStream{rows: rows}, []string{"id"})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, produced)
+	assert.Equal(t, "id\n1\n2\n3\n", b.String())
+}
+
+func TestContextWriter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := bytes.NewBufferString("")
+	w := ContextWriter(ctx, b)
+
+	_, err := w.Write([]byte("one\n"))
+	assert.Nil(t, err)
+
+	cancel()
+
+	_, err = w.Write([]byte("two\n"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "one\n", b.String())
+}
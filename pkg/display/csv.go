@@ -0,0 +1,117 @@
+package display
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Delimiter separated output formats.
+const (
+	CSVFormat = "csv"
+	TSVFormat = "tsv"
+)
+
+func init() {
+	RegisterFormat(CSVFormat, SimpleFormat(CSVDisplayer))
+	RegisterFormat(TSVFormat, SimpleFormat(TSVDisplayer))
+}
+
+type csvDisplayer struct {
+	output io.Writer
+	comma  rune
+}
+
+// Display writes the selected columns of every row as
+// delimiter separated records, quoting values when needed.
+func (cd *csvDisplayer) Display(d Displayable, f []string) error {
+	w := csv.NewWriter(cd.output)
+	w.Comma = cd.comma
+
+	cols := getCols(d, f)
+
+	if !d.NoHeaders() {
+		if err := w.Write(cols); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(cols))
+
+	for _, r := range d.KV() {
+		for i, col := range cols {
+			record[i] = plainValue(r[col])
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (cd *csvDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(cd, ds, f)
+}
+
+func (cd *csvDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *cd
+	t.output = wrap(cd.output)
+
+	return &t
+}
+
+// CSVDisplayer renders displayables as RFC 4180 comma separated
+// values to the provided writer (defaults to os.Stdout).
+func CSVDisplayer(output io.Writer) Displayer {
+	return &csvDisplayer{
+		output: stdout(output),
+		comma:  ',',
+	}
+}
+
+// TSVDisplayer renders displayables as tab separated values
+// to the provided writer (defaults to os.Stdout).
+//
+// Values containing tabs, quotes or line breaks are quoted
+// following the same rules used for CSV.
+func TSVDisplayer(output io.Writer) Displayer {
+	return &csvDisplayer{
+		output: stdout(output),
+		comma:  '\t',
+	}
+}
+
+// plainValue converts a value into its machine readable
+// string representation.
+func plainValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case time.Time:
+		return t.Format(time.RFC3339)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+
+		return t.Format(time.RFC3339)
+	case CellChange:
+		return plainValue(t.Before) + " → " + plainValue(t.After)
+	default:
+		return fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,84 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var notes = []map[string]interface{}{
+	{"id": 1, "note": "hello, world", "at": time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)},
+	{"id": 2, "note": "say \"hi\"\nthen\tleave", "at": nil},
+}
+
+func TestDisplay_CSVDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(notes)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "note", "at"})
+	m.EXPECT().NoHeaders().Return(false)
+
+	want := "id,note,at\n" +
+		"1,\"hello, world\",2021-05-01T10:00:00Z\n" +
+		"2,\"say \"\"hi\"\"\nthen\tleave\",\n"
+
+	b := bytes.NewBufferString("")
+
+	err := CSVDisplayer(b).Display(m, []string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_TSVDisplayer_FilteredNoHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(notes)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "note", "at"})
+	m.EXPECT().NoHeaders().Return(true)
+	m.EXPECT().Filterable().Return(true)
+
+	want := "hello, world\t1\n" +
+		"\"say \"\"hi\"\"\nthen\tleave\"\t2\n"
+
+	b := bytes.NewBufferString("")
+
+	err := TSVDisplayer(b).Display(m, []string{"note", "id"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestPlainValue(t *testing.T) {
+	at := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		given interface{}
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"string", "text", "text"},
+		{"int", 10, "10"},
+		{"float without trailing zeros", 1.22, "1.22"},
+		{"bool", true, "true"},
+		{"time", at, "2021-05-01T10:00:00Z"},
+		{"time pointer", &at, "2021-05-01T10:00:00Z"},
+		{"nil time pointer", (*time.Time)(nil), ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, plainValue(c.given))
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DescribeFormat renders every row as a list of key value pairs.
+const DescribeFormat = "describe"
+
+func init() {
+	RegisterFormat(DescribeFormat, SimpleFormat(DescribeDisplayer))
+}
+
+const describeIndent = "  "
+
+type describeDisplayer struct {
+	output io.Writer
+	exact  bool
+}
+
+// Display renders every row of the displayable as aligned
+// "Key: value" lines, separating rows with a blank line.
+func (dd *describeDisplayer) Display(d Displayable, f []string) error {
+	cols := getCols(d, f)
+	desc := d.ColMap()
+
+	labels := make([]string, len(cols))
+	for i, col := range cols {
+		labels[i] = col
+		if l := desc[col]; l != "" {
+			labels[i] = l
+		}
+	}
+
+	var b strings.Builder
+
+	for i, r := range d.KV() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		values := make([]interface{}, len(cols))
+		for j, col := range cols {
+			values[j] = r[col]
+		}
+
+		writeDescribed(&b, "", cols, labels, values, humanized(dd.exact))
+
+		if _, err := io.WriteString(dd.output, b.String()); err != nil {
+			return err
+		}
+
+		b.Reset()
+	}
+
+	return nil
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (dd *describeDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(dd, ds, f)
+}
+
+func (dd *describeDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *dd
+	t.output = wrap(dd.output)
+
+	return &t
+}
+
+func (dd *describeDisplayer) exactCopy() Displayer {
+	return &describeDisplayer{output: dd.output, exact: true}
+}
+
+// DescribeDisplayer renders each row of a displayable vertically,
+// as aligned "Key: value" lines, to the provided writer (defaults
+// to os.Stdout).
+//
+// Column descriptions from ColMap are used as labels when present,
+// nested maps and slices are rendered indented below their key.
+func DescribeDisplayer(output io.Writer) Displayer {
+	return &describeDisplayer{
+		output: stdout(output),
+	}
+}
+
+type autoDescribeDisplayer struct {
+	Displayer
+	describe Displayer
+}
+
+// Display uses the describe layout when the displayable has a
+// single row and the wrapped displayer otherwise.
+func (ad *autoDescribeDisplayer) Display(d Displayable, f []string) error {
+	rows := &staticRows{Displayable: d, rows: d.KV()}
+
+	if len(rows.rows) == 1 {
+		return ad.describe.Display(rows, f)
+	}
+
+	return ad.Displayer.Display(rows, f)
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (ad *autoDescribeDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(ad, ds, f)
+}
+
+func (ad *autoDescribeDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	return &autoDescribeDisplayer{
+		Displayer: wrapDisplayerOutput(ad.Displayer, wrap),
+		describe:  wrapDisplayerOutput(ad.describe, wrap),
+	}
+}
+
+func (ad *autoDescribeDisplayer) exactCopy() Displayer {
+	return &autoDescribeDisplayer{
+		Displayer: ExactDisplayer(ad.Displayer),
+		describe:  ExactDisplayer(ad.describe),
+	}
+}
+
+// AutoDescribe wraps a displayer so displayables returning exactly
+// one row are rendered with the describe layout to the provided
+// writer (defaults to os.Stdout).
+func AutoDescribe(dsp Displayer, output io.Writer) Displayer {
+	return &autoDescribeDisplayer{
+		Displayer: dsp,
+		describe:  DescribeDisplayer(output),
+	}
+}
+
+// staticRows is a displayable whose rows were already retrieved.
+type staticRows struct {
+	Displayable
+	rows []map[string]interface{}
+}
+
+// KV is a displayable group of key value.
+func (sr *staticRows) KV() []map[string]interface{} {
+	return sr.rows
+}
+
+// writeDescribed writes the labeled values, cols holds the
+// column of each value and is nil for nested values.
+func writeDescribed(b *strings.Builder, indent string, cols, labels []string, values []interface{}, human bool) {
+	width := 0
+	for _, l := range labels {
+		if w := StringWidth(l); w > width {
+			width = w
+		}
+	}
+
+	for i, l := range labels {
+		key := indent + l + ":"
+
+		if nested, ok := describeNested(values[i], indent+describeIndent, human); ok {
+			b.WriteString(key + "\n" + nested)
+
+			continue
+		}
+
+		col := ""
+		if cols != nil {
+			col = cols[i]
+		}
+
+		text := formatValue(col, values[i], human)
+		pad := strings.Repeat(" ", width-StringWidth(l)+2)
+		cont := "\n" + strings.Repeat(" ", StringWidth(key)+len(pad))
+
+		b.WriteString(key + pad + strings.ReplaceAll(text, "\n", cont) + "\n")
+	}
+}
+
+// describeNested renders maps and slices, reporting false for
+// any other value or for empty collections.
+func describeNested(v interface{}, indent string, human bool) (string, bool) {
+	rv := reflect.ValueOf(v)
+
+	var b strings.Builder
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Len() == 0 {
+			return "", false
+		}
+
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+
+		labels := make([]string, len(keys))
+		values := make([]interface{}, len(keys))
+
+		for i, k := range keys {
+			labels[i] = fmt.Sprint(k)
+			values[i] = rv.MapIndex(k).Interface()
+		}
+
+		writeDescribed(&b, indent, nil, labels, values, human)
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 || rv.Type().Elem().Kind() == reflect.Uint8 {
+			return "", false
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i).Interface()
+
+			nested, ok := describeNested(item, indent+describeIndent, human)
+			if !ok {
+				b.WriteString(indent + "- " + strings.ReplaceAll(cellValue(item, human), "\n", "\n"+indent+"  ") + "\n")
+
+				continue
+			}
+
+			// the first line of nested maps shares the line of the dash.
+			b.WriteString(indent + "- " + strings.TrimPrefix(nested, indent+describeIndent))
+		}
+	default:
+		return "", false
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,118 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_DescribeDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies[:2])
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().ColMap().Return(map[string]string{"Quote": "Exchange rate"})
+
+	want := `Symbol:         EUR
+Exchange rate:  1
+
+Symbol:         USD
+Exchange rate:  1.22
+`
+
+	b := bytes.NewBufferString("")
+
+	err := DescribeDisplayer(b).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_DescribeDisplayer_Nested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return([]map[string]interface{}{
+		{
+			"name":   "admiral",
+			"notes":  "first line\nsecond line",
+			"labels": map[string]string{"team": "cli", "env": "prod"},
+			"tags":   []string{"go", "cobra"},
+			"owners": []map[string]interface{}{
+				{"name": "avocatl", "role": "admin"},
+			},
+			"empty": []string{},
+		},
+	})
+	m.EXPECT().Cols().AnyTimes().Return([]string{"name", "notes", "labels", "tags", "owners", "empty"})
+	m.EXPECT().ColMap().Return(map[string]string{})
+
+	want := `name:    admiral
+notes:   first line
+         second line
+labels:
+  env:   prod
+  team:  cli
+tags:
+  - go
+  - cobra
+owners:
+  - name:  avocatl
+    role:  admin
+empty:   []
+`
+
+	b := bytes.NewBufferString("")
+
+	err := DescribeDisplayer(b).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_AutoDescribe(t *testing.T) {
+	cases := []struct {
+		name string
+		rows []map[string]interface{}
+		want string
+	}{
+		{
+			"single row is described",
+			currencies[:1],
+			"Symbol:  EUR\nQuote:   1\n",
+		},
+		{
+			"multiple rows use the table",
+			currencies[:2],
+			"Symbol    Quote\nEUR       1\nUSD       1.22\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDisplayable(ctrl)
+
+			m.EXPECT().KV().Times(1).Return(c.rows)
+			m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+			m.EXPECT().ColMap().AnyTimes().Return(map[string]string{})
+			m.EXPECT().NoHeaders().AnyTimes().Return(false)
+
+			b := bytes.NewBufferString("")
+
+			err := AutoDescribe(DefaultDisplayer(b), b).Display(m, nil)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, b.String())
+		})
+	}
+}
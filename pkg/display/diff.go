@@ -0,0 +1,535 @@
+package display
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Diff errors.
+var (
+	ErrDuplicateKey = errors.New("duplicate key")
+	errNotDiff      = errors.New("displayable is not a difference, use Diff to create one")
+)
+
+// DiffChangeCol is the column holding the change marker of
+// every row of a difference.
+const DiffChangeCol = "change"
+
+// Change describes what happened to a row.
+type Change string
+
+// Row changes.
+const (
+	ChangeAdded   Change = "added"
+	ChangeRemoved Change = "removed"
+	ChangeChanged Change = "changed"
+)
+
+var changeMarks = map[Change]string{
+	ChangeAdded:   "+",
+	ChangeRemoved: "-",
+	ChangeChanged: "~",
+}
+
+// CellChange holds the values of a column that changed.
+//
+// Text formats write it as "before → after", formatting both
+// values as any other value of the column.
+type CellChange struct {
+	Col    string      `json:"column" yaml:"column"`
+	Before interface{} `json:"before" yaml:"before"`
+	After  interface{} `json:"after" yaml:"after"`
+}
+
+// RowChange describes a row that was added, removed or changed.
+//
+// Before is nil for added rows and After is nil for removed rows.
+type RowChange struct {
+	Key    interface{}            `json:"key"`
+	Change Change                 `json:"change"`
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+	Cells  []CellChange           `json:"cells,omitempty"`
+}
+
+// Difference holds the rows that differ between two displayables.
+//
+// It is a displayable itself, with a change column (+, - or ~)
+// followed by the compared columns. Changed values are held as
+// CellChange, so every format renders them its own way.
+type Difference struct {
+	Key    string
+	Rows   []RowChange
+	cols   []string
+	colMap map[string]string
+}
+
+// Diff compares the rows of two displayables matching them by
+// the value of the key column.
+//
+// Columns keep the order returned by Cols, columns only present
+// before are placed last. Removed and changed rows keep the order
+// they had before, added rows follow in the order they have after.
+//
+// An error wrapping ErrUnknownColumn is returned when the key is
+// not part of both displayables and one wrapping ErrDuplicateKey
+// when it does not identify a single row.
+func Diff(before, after Displayable, key string) (*Difference, error) {
+	for _, d := range []Displayable{before, after} {
+		if err := checkColumn(d, key); err != nil {
+			return nil, err
+		}
+	}
+
+	cols := append([]string{}, after.Cols()...)
+	colMap := map[string]string{}
+
+	for _, d := range []Displayable{before, after} {
+		for c, desc := range d.ColMap() {
+			colMap[c] = desc
+		}
+	}
+
+	for _, c := range before.Cols() {
+		if !contains(cols, c) {
+			cols = append(cols, c)
+		}
+	}
+
+	afterRows, afterKeys, err := keyedRows(after.KV(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeKV := before.KV()
+	if _, _, err := keyedRows(beforeKV, key); err != nil {
+		return nil, err
+	}
+
+	diff := &Difference{Key: key, cols: cols, colMap: colMap}
+	seen := map[string]bool{}
+
+	for _, r := range beforeKV {
+		k := valueKey(r[key])
+		seen[k] = true
+
+		a, ok := afterRows[k]
+		if !ok {
+			diff.Rows = append(diff.Rows, RowChange{Key: r[key], Change: ChangeRemoved, Before: r})
+
+			continue
+		}
+
+		var cells []CellChange
+
+		for _, c := range cols {
+			if !equalValues(r[c], a[c]) {
+				cells = append(cells, CellChange{Col: c, Before: r[c], After: a[c]})
+			}
+		}
+
+		if len(cells) > 0 {
+			diff.Rows = append(diff.Rows, RowChange{Key: r[key], Change: ChangeChanged, Before: r, After: a, Cells: cells})
+		}
+	}
+
+	for _, k := range afterKeys {
+		if !seen[k] {
+			a := afterRows[k]
+			diff.Rows = append(diff.Rows, RowChange{Key: a[key], Change: ChangeAdded, After: a})
+		}
+	}
+
+	return diff, nil
+}
+
+// keyedRows indexes the rows by the value of the key column,
+// returning the keys in order of appearance.
+func keyedRows(rows []map[string]interface{}, key string) (map[string]map[string]interface{}, []string, error) {
+	indexed := make(map[string]map[string]interface{}, len(rows))
+	keys := make([]string, 0, len(rows))
+
+	for _, r := range rows {
+		k := valueKey(r[key])
+		if _, ok := indexed[k]; ok {
+			return nil, nil, fmt.Errorf("%w %v on column %s", ErrDuplicateKey, r[key], key)
+		}
+
+		indexed[k] = r
+		keys = append(keys, k)
+	}
+
+	return indexed, keys, nil
+}
+
+// valueKey identifies a value by its type and representation.
+func valueKey(v interface{}) string {
+	return fmt.Sprintf("%T:%v", v, v)
+}
+
+func equalValues(a, b interface{}) bool {
+	if fa, ok := numeric(a); ok {
+		if fb, ok := numeric(b); ok {
+			return fa == fb
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Select returns the difference restricted to the provided
+// columns, the key column is always kept. Changed rows whose
+// changes are all on other columns are left out.
+func (d *Difference) Select(cols []string) *Difference {
+	if len(cols) == 0 {
+		return d
+	}
+
+	selected := append([]string{}, cols...)
+	if !contains(selected, d.Key) {
+		selected = append([]string{d.Key}, selected...)
+	}
+
+	s := &Difference{Key: d.Key, cols: selected, colMap: d.colMap}
+
+	for _, r := range d.Rows {
+		if r.Change != ChangeChanged {
+			s.Rows = append(s.Rows, r)
+
+			continue
+		}
+
+		var cells []CellChange
+
+		for _, c := range r.Cells {
+			if contains(selected, c.Col) {
+				cells = append(cells, c)
+			}
+		}
+
+		if len(cells) > 0 {
+			r.Cells = cells
+			s.Rows = append(s.Rows, r)
+		}
+	}
+
+	return s
+}
+
+// Empty reports whether both displayables held the same rows.
+func (d *Difference) Empty() bool {
+	return len(d.Rows) == 0
+}
+
+// KV returns a row for each change.
+func (d *Difference) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(d.Rows))
+
+	for _, r := range d.Rows {
+		row := map[string]interface{}{DiffChangeCol: changeMarks[r.Change]}
+
+		values := r.After
+		if r.Change == ChangeRemoved {
+			values = r.Before
+		}
+
+		for _, c := range d.cols {
+			row[c] = values[c]
+		}
+
+		for _, c := range r.Cells {
+			row[c.Col] = c
+		}
+
+		kv = append(kv, row)
+	}
+
+	return kv
+}
+
+// Cols returns the change column followed by the compared columns.
+func (d *Difference) Cols() []string {
+	return append([]string{DiffChangeCol}, d.cols...)
+}
+
+// ColMap describes the columns of the difference.
+func (d *Difference) ColMap() map[string]string {
+	m := map[string]string{DiffChangeCol: "Whether the row was added (+), removed (-) or changed (~)"}
+
+	for _, c := range d.cols {
+		m[c] = d.colMap[c]
+	}
+
+	return m
+}
+
+// NoHeaders reports whether the headers are hidden.
+func (d *Difference) NoHeaders() bool {
+	return false
+}
+
+// Filterable reports whether the columns can be selected.
+func (d *Difference) Filterable() bool {
+	return true
+}
+
+// DiffFormat selects how a difference is rendered.
+type DiffFormat int
+
+// Supported difference layouts.
+const (
+	DiffTable DiffFormat = iota
+	DiffUnified
+	DiffJSON
+)
+
+// DiffConfig customizes the difference output.
+type DiffConfig struct {
+	Format DiffFormat
+	// Table configures the table layout, its color mode is
+	// honored by the unified layout too.
+	Table TableConfig
+}
+
+type diffDisplayer struct {
+	output io.Writer
+	config DiffConfig
+	table  *stdDisplayer
+}
+
+// Display renders a difference created by Diff, restricted to
+// the requested columns, looking through the wrappers decorating
+// it.
+func (dd *diffDisplayer) Display(d Displayable, f []string) error {
+	diff, ok := difference(d)
+	if !ok {
+		return errNotDiff
+	}
+
+	// wrappers may have selected the columns, e.g. the fields flag.
+	var cols []string
+
+	for _, c := range getCols(d, f) {
+		if c != DiffChangeCol {
+			cols = append(cols, c)
+		}
+	}
+
+	diff = diff.Select(cols)
+
+	switch dd.config.Format {
+	case DiffUnified:
+		return dd.unified(diff)
+	case DiffJSON:
+		return dd.json(diff)
+	default:
+		return dd.tabular(diff)
+	}
+}
+
+// difference returns the displayable created with Diff, if any,
+// looking through the wrappers decorating it.
+func difference(d Displayable) (*Difference, bool) {
+	for {
+		switch t := d.(type) {
+		case *Difference:
+			return t, true
+		case Wrapper:
+			d = t.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (dd *diffDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(dd, ds, f)
+}
+
+func (dd *diffDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *dd
+	t.output = wrap(dd.output)
+	t.table = wrapDisplayerOutput(dd.table, wrap).(*stdDisplayer)
+
+	return &t
+}
+
+func (dd *diffDisplayer) exactCopy() Displayer {
+	table := *dd.table
+	table.config.Exact = true
+
+	t := *dd
+	t.config.Table.Exact = true
+	t.table = &table
+
+	return &t
+}
+
+var changeColors = map[Change]Color{
+	ChangeAdded:   Green,
+	ChangeRemoved: Red,
+	ChangeChanged: Yellow,
+}
+
+func (dd *diffDisplayer) tabular(diff *Difference) error {
+	cols := diff.Cols()
+	cells, styles := tableCells(diff, cols, nil, humanized(dd.table.config.Exact))
+
+	if dd.table.colored {
+		for i, r := range diff.Rows {
+			row := styles[i+1]
+
+			for c := range row {
+				switch {
+				case r.Change != ChangeChanged:
+					row[c].Color = changeColors[r.Change]
+				case c == 0 || changedCell(r, cols[c]):
+					row[c].Color = Yellow
+				}
+			}
+		}
+	}
+
+	return dd.table.render(cols, cells, styles)
+}
+
+func changedCell(r RowChange, col string) bool {
+	for _, c := range r.Cells {
+		if c.Col == col {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unified writes a line per value prefixed with the change
+// marker, changed rows write their key as context followed by
+// the removed and added values of every changed column.
+func (dd *diffDisplayer) unified(diff *Difference) error {
+	var b strings.Builder
+
+	line := func(change Change, text string) {
+		mark := " "
+		if change != "" {
+			mark = changeMarks[change]
+		}
+
+		text = mark + " " + text
+		if dd.table.colored && change != "" {
+			text = Style{Color: changeColors[change]}.Render(text)
+		}
+
+		b.WriteString(text + "\n")
+	}
+
+	for _, r := range diff.Rows {
+		human := humanized(dd.table.config.Exact)
+		key := diff.Key + ": " + formatValue(diff.Key, r.Key, human)
+
+		switch r.Change {
+		case ChangeChanged:
+			line("", key)
+
+			for _, c := range r.Cells {
+				line(ChangeRemoved, "  "+c.Col+": "+formatValue(c.Col, c.Before, human))
+				line(ChangeAdded, "  "+c.Col+": "+formatValue(c.Col, c.After, human))
+			}
+		default:
+			values := r.After
+			if r.Change == ChangeRemoved {
+				values = r.Before
+			}
+
+			line(r.Change, key)
+
+			for _, c := range diff.cols {
+				if c != diff.Key {
+					line(r.Change, "  "+c+": "+formatValue(c, values[c], human))
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(dd.output, b.String())
+
+	return err
+}
+
+type diffSummary struct {
+	Added   int         `json:"added"`
+	Removed int         `json:"removed"`
+	Changed int         `json:"changed"`
+	Rows    []RowChange `json:"rows"`
+}
+
+func (dd *diffDisplayer) json(diff *Difference) error {
+	summary := diffSummary{Rows: make([]RowChange, 0, len(diff.Rows))}
+
+	for _, r := range diff.Rows {
+		switch r.Change {
+		case ChangeAdded:
+			summary.Added++
+		case ChangeRemoved:
+			summary.Removed++
+		default:
+			summary.Changed++
+		}
+
+		r.Before = pickCols(r.Before, diff.cols)
+		r.After = pickCols(r.After, diff.cols)
+
+		summary.Rows = append(summary.Rows, r)
+	}
+
+	enc := json.NewEncoder(dd.output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	return enc.Encode(summary)
+}
+
+func pickCols(r map[string]interface{}, cols []string) map[string]interface{} {
+	if r == nil {
+		return nil
+	}
+
+	picked := make(map[string]interface{}, len(cols))
+	for _, c := range cols {
+		picked[c] = nestedRows(r[c])
+	}
+
+	return picked
+}
+
+// DiffDisplayer renders differences created by Diff to the
+// provided writer (defaults to os.Stdout) as a table, a unified
+// text diff or JSON.
+//
+// Added rows are green, removed rows red and changed values
+// yellow when colors are enabled.
+func DiffDisplayer(output io.Writer, config DiffConfig) Displayer {
+	output = stdout(output)
+
+	return &diffDisplayer{
+		output: output,
+		config: config,
+		table:  TableDisplayer(output, config.Table).(*stdDisplayer),
+	}
+}
@@ -0,0 +1,157 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func planDisplayables() (Displayable, Displayable) {
+	before := &staticDisplayable{
+		cols: []string{"id", "name", "status"},
+		rows: []map[string]interface{}{
+			{"id": 1, "name": "api", "status": "open"},
+			{"id": 2, "name": "cli", "status": "open"},
+			{"id": 3, "name": "web", "status": "closed"},
+		},
+	}
+
+	after := &staticDisplayable{
+		cols: []string{"id", "name", "status"},
+		rows: []map[string]interface{}{
+			{"id": 1, "name": "api", "status": "paid"},
+			{"id": 2, "name": "cli", "status": "open"},
+			{"id": 4, "name": "docs", "status": "open"},
+		},
+	}
+
+	return before, after
+}
+
+func TestDiff(t *testing.T) {
+	before, after := planDisplayables()
+
+	diff, err := Diff(before, after, "id")
+
+	assert.Nil(t, err)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []string{"change", "id", "name", "status"}, diff.Cols())
+	assert.Equal(t, []map[string]interface{}{
+		{"change": "~", "id": 1, "name": "api", "status": CellChange{Col: "status", Before: "open", After: "paid"}},
+		{"change": "-", "id": 3, "name": "web", "status": "closed"},
+		{"change": "+", "id": 4, "name": "docs", "status": "open"},
+	}, diff.KV())
+
+	// the changed row only differs on the status.
+	selected := diff.Select([]string{"name"})
+	assert.Equal(t, []string{"change", "id", "name"}, selected.Cols())
+	assert.Len(t, selected.Rows, 2)
+
+	_, err = Diff(before, after, "uuid")
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	_, err = Diff(before, &staticDisplayable{
+		cols: []string{"id"},
+		rows: []map[string]interface{}{{"id": 1}, {"id": 1}},
+	}, "id")
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+}
+
+func TestDiffDisplayer_Table(t *testing.T) {
+	before, after := planDisplayables()
+	diff, _ := Diff(before, after, "id")
+
+	want := "change    id    status\n" +
+		"~         1     open → paid\n" +
+		"-         3     closed\n" +
+		"+         4     open\n"
+
+	b := bytes.NewBufferString("")
+
+	err := DiffDisplayer(b, DiffConfig{}).Display(diff, []string{"status"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDiffDisplayer_Unified(t *testing.T) {
+	before, after := planDisplayables()
+	diff, _ := Diff(before, after, "id")
+
+	want := "  id: 1\n" +
+		"-   status: open\n" +
+		"+   status: paid\n" +
+		"- id: 3\n" +
+		"-   name: web\n" +
+		"-   status: closed\n" +
+		"+ id: 4\n" +
+		"+   name: docs\n" +
+		"+   status: open\n"
+
+	b := bytes.NewBufferString("")
+
+	err := DiffDisplayer(b, DiffConfig{Format: DiffUnified}).Display(diff, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDiffDisplayer_JSON(t *testing.T) {
+	before, after := planDisplayables()
+	diff, _ := Diff(before, after, "id")
+
+	b := bytes.NewBufferString("")
+
+	err := DiffDisplayer(b, DiffConfig{Format: DiffJSON}).Display(diff, []string{"status"})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"added": 1, "removed": 1, "changed": 1,
+		"rows": [
+			{"key": 1, "change": "changed",
+			 "before": {"id": 1, "status": "open"}, "after": {"id": 1, "status": "paid"},
+			 "cells": [{"column": "status", "before": "open", "after": "paid"}]},
+			{"key": 3, "change": "removed", "before": {"id": 3, "status": "closed"}},
+			{"key": 4, "change": "added", "after": {"id": 4, "status": "open"}}
+		]
+	}`, b.String())
+
+	err = DiffDisplayer(b, DiffConfig{}).Display(before, nil)
+	assert.Error(t, err)
+}
+
+func TestDiffDisplayer_Values(t *testing.T) {
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	before := &staticDisplayable{
+		cols: []string{"id", "since", "ratio"},
+		rows: []map[string]interface{}{{"id": 1, "since": since, "ratio": 1.5}},
+	}
+
+	after := &staticDisplayable{
+		cols: []string{"id", "since", "ratio"},
+		rows: []map[string]interface{}{{"id": 1, "since": since.Add(time.Hour), "ratio": 2.25}},
+	}
+
+	diff, _ := Diff(before, after, "id")
+
+	b := bytes.NewBufferString("")
+	assert.Nil(t, CSVDisplayer(b).Display(diff, nil))
+	assert.Equal(t, "change,id,since,ratio\n"+
+		"~,1,2021-01-01T00:00:00Z → 2021-01-01T01:00:00Z,1.5 → 2.25\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, ExactDisplayer(DiffDisplayer(b, DiffConfig{})).Display(diff, []string{"since"}))
+	assert.Equal(t, "change    id    since\n"+
+		"~         1     2021-01-01T00:00:00Z → 2021-01-01T01:00:00Z\n", b.String())
+
+	// wrappers decorating the difference are looked through.
+	sorted, err := Sorted(diff, ParseSortKeys("id")...)
+	assert.Nil(t, err)
+
+	b.Reset()
+	assert.Nil(t, DiffDisplayer(b, DiffConfig{Format: DiffUnified}).Display(sorted, []string{"ratio"}))
+	assert.Equal(t, "  id: 1\n-   ratio: 1.5\n+   ratio: 2.25\n", b.String())
+}
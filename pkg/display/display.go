@@ -1,11 +1,10 @@
 package display
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"os"
 	"strings"
-	"text/tabwriter"
 )
 
 // Displayable describes an output handler for
@@ -26,17 +25,24 @@ type Displayer interface {
 }
 
 type stdDisplayer struct {
-	output io.Writer
+	output  io.Writer
+	config  TableConfig
+	colored bool
 }
 
 // Display returns an error if the action of
 // printing output to the CLI fails.
 func (sd *stdDisplayer) Display(d Displayable, f []string) error {
-	w := newTabWritter(sd.output)
+	cols := getCols(d, f)
+
+	var style StyleFunc
+	if sd.colored {
+		style = sd.config.Style
+	}
 
-	displayablePrinter(d, w, f)
+	cells, styles := tableCells(d, cols, style, humanized(sd.config.Exact))
 
-	return w.Flush()
+	return sd.render(cols, cells, styles)
 }
 
 // DisplayMany executes the displaying process on multiple
@@ -45,9 +51,37 @@ func (sd *stdDisplayer) Display(d Displayable, f []string) error {
 // A popular use case is an object with nested objects inside
 // each of which requires a specific dispaying structure.
 func (sd *stdDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(sd, ds, f)
+}
+
+func (sd *stdDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *sd
+	t.output = wrap(sd.output)
+
+	return &t
+}
+
+func (sd *stdDisplayer) exactCopy() Displayer {
+	t := *sd
+	t.config.Exact = true
+
+	return &t
+}
+
+// DisplayContext renders the displayable, stopping before the
+// next row is written once the context is done.
+func (sd *stdDisplayer) DisplayContext(ctx context.Context, d Displayable, f []string) error {
+	t := *sd
+	t.output = ContextWriter(ctx, sd.output)
+
+	return t.Display(d, f)
+}
+
+// DisplayManyContext renders every displayable until the context
+// is done.
+func (sd *stdDisplayer) DisplayManyContext(ctx context.Context, ds []Displayable, f []string) error {
 	for _, d := range ds {
-		err := sd.Display(d, f)
-		if err != nil {
+		if err := sd.DisplayContext(ctx, d, f); err != nil {
 			return err
 		}
 	}
@@ -55,49 +89,65 @@ func (sd *stdDisplayer) DisplayMany(ds []Displayable, f []string) error {
 	return nil
 }
 
-func newTabWritter(output io.Writer) *tabwriter.Writer {
-	w := new(tabwriter.Writer)
-	w.Init(output, 0, 0, 4, ' ', 0)
+func displayEach(dsp Displayer, ds []Displayable, f []string) error {
+	for _, d := range ds {
+		err := dsp.Display(d, f)
+		if err != nil {
+			return err
+		}
+	}
 
-	return w
+	return nil
 }
 
-func displayablePrinter(d Displayable, w io.Writer, f []string) {
-	cols := getCols(d, f)
+// tableCells returns the header, unless disabled, followed
+// by the formatted values of every row along with their styles.
+func tableCells(d Displayable, cols []string, style StyleFunc, human bool) ([][]string, [][]Style) {
+	var (
+		cells  [][]string
+		styles [][]Style
+	)
 
 	if !d.NoHeaders() {
-		fmt.Fprintln(w, strings.Join(cols, "\t"))
+		cells = append(cells, cols)
+		styles = append(styles, make([]Style, len(cols)))
 	}
 
 	for _, r := range d.KV() {
-		values := []interface{}{}
-		formats := []string{}
-
-		for _, col := range cols {
-			v := r[col]
-			values = append(values, v)
-
-			switch v.(type) {
-			case string:
-				formats = append(formats, "%s")
-			case int:
-				formats = append(formats, "%d")
-			case float64:
-				formats = append(formats, "%f")
-			case bool:
-				formats = append(formats, "%v")
-			default:
-				formats = append(formats, "%v")
+		values := make([]string, len(cols))
+		rs := make([]Style, len(cols))
+
+		for i, col := range cols {
+			values[i] = formatValue(col, r[col], human)
+
+			if style == nil {
+				continue
 			}
-		}
 
-		format := strings.Join(formats, "\t")
+			rs[i] = style(col, r[col])
+			if rs[i].Text != "" {
+				values[i] = rs[i].Text
+			}
+		}
 
-		fmt.Fprintf(w, format+"\n", values...)
+		cells = append(cells, values)
+		styles = append(styles, rs)
 	}
+
+	return cells, styles
+}
+
+func cellValue(v interface{}, human bool) string {
+	return formatValue("", v, human)
 }
 
-func getCols(d Displayable, f []string) []string {
+// columnar is implemented by displayables and streamables.
+type columnar interface {
+	Cols() []string
+	Filterable() bool
+}
+
+func getCols(d columnar, f []string) []string {
 	var cols []string
 	{
 		cols = d.Cols()
@@ -113,15 +163,22 @@ func getCols(d Displayable, f []string) []string {
 // to the provided writer (defaults to os.Stdout).
 //
 // The output appearance is similar to the one provided
-// by docker's cli.
+// by docker's cli. When writing to a terminal the values
+// are truncated to fit its width.
 func DefaultDisplayer(output io.Writer) Displayer {
+	output = stdout(output)
+
+	return TableDisplayer(output, TableConfig{
+		Width: TerminalWidth(output),
+	})
+}
+
+func stdout(output io.Writer) io.Writer {
 	if output == nil {
-		output = os.Stdout
+		return os.Stdout
 	}
 
-	return &stdDisplayer{
-		output: output,
-	}
+	return output
 }
 
 // FilterColumns will check if the filterable flag is used
@@ -130,6 +187,9 @@ func DefaultDisplayer(output io.Writer) Displayer {
 // It takes the string parsed from the filterable flag as
 // first argument and the default set of columns (default)
 // as second parameter.
+//
+// FilterColumns does not validate the requested columns,
+// use ResolveFields to reject unknown ones.
 func FilterColumns(req string, def []string) []string {
 	if req != "" {
 		return strings.Split(req, ",")
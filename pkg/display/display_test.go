@@ -52,8 +52,8 @@ func TestDisplay_DefaultDisplayer_Content(t *testing.T) {
 
 	want := `Symbol    Quote
 EUR       1
-USD       1.220000
-MXN       24.450000
+USD       1.22
+MXN       24.45
 `
 
 	b := bytes.NewBufferString("")
@@ -120,7 +120,7 @@ func TestDisplay_DefaultDisplayer_NoHeaders(t *testing.T) {
 	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
 	m.EXPECT().NoHeaders().Return(true)
 
-	want := "EUR    1\nUSD    1.220000\nMXN    24.450000\n"
+	want := "EUR    1\nUSD    1.22\nMXN    24.45\n"
 
 	b := bytes.NewBufferString("")
 
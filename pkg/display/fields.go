@@ -0,0 +1,210 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrUnknownField is returned when the requested fields are
+// not part of the displayable columns.
+var ErrUnknownField = errors.New("unknown field")
+
+// ErrNoFields is returned when the requested fields remove every
+// column from the displayable.
+var ErrNoFields = errors.New("no fields left to display")
+
+// SuggestMinDistance is the maximum edit distance between an
+// unknown field and a column for it to be suggested.
+const SuggestMinDistance = 2
+
+// ResolveFields validates the fields requested through the
+// filterable flag and returns the set of columns to display.
+//
+// Fields are separated by commas, those prefixed with '+' are
+// added to the default set of columns (Cols) and those prefixed
+// with '-' are removed from it, e.g. "+email,-id". Any field that
+// is not part of Cols or ColMap produces an error suggesting
+// similar fields and describing the available ones, and so does
+// a request removing every column.
+//
+// Displayables that are not filterable always use their default
+// set of columns.
+func ResolveFields(req string, d Displayable) ([]string, error) {
+	if strings.TrimSpace(req) == "" || !d.Filterable() {
+		return d.Cols(), nil
+	}
+
+	available := availableCols(d)
+	known := make(map[string]bool, len(available))
+
+	for _, c := range available {
+		known[c] = true
+	}
+
+	var (
+		cols    []string
+		add     []string
+		remove  = map[string]bool{}
+		unknown []string
+	)
+
+	for _, f := range strings.Split(req, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+
+		name := strings.TrimLeft(f, "+-")
+		if !known[name] {
+			unknown = append(unknown, name)
+
+			continue
+		}
+
+		switch f[0] {
+		case '+':
+			add = append(add, name)
+		case '-':
+			remove[name] = true
+		default:
+			cols = append(cols, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, unknownFieldsError(unknown, available, d.ColMap())
+	}
+
+	if cols == nil {
+		cols = append(cols, d.Cols()...)
+	}
+
+	var out []string
+
+	seen := map[string]bool{}
+
+	for _, c := range append(cols, add...) {
+		if remove[c] || seen[c] {
+			continue
+		}
+
+		seen[c] = true
+		out = append(out, c)
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w, %q removes every column", ErrNoFields, req)
+	}
+
+	return out, nil
+}
+
+func unknownFieldsError(unknown, available []string, desc map[string]string) error {
+	var b strings.Builder
+
+	for i, u := range unknown {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "%q", u)
+
+		if s := SuggestFields(u, available); len(s) > 0 {
+			fmt.Fprintf(&b, ", did you mean %q?", s[0])
+		}
+	}
+
+	b.WriteString("\n\nAvailable fields:\n")
+
+	var fields strings.Builder
+
+	w := tabwriter.NewWriter(&fields, 0, 0, 4, ' ', 0)
+	for _, c := range available {
+		fmt.Fprintf(w, "  %s\t%s\n", c, desc[c])
+	}
+
+	_ = w.Flush()
+
+	// fields without a description are not padded.
+	for _, l := range strings.Split(fields.String(), "\n") {
+		b.WriteString(strings.TrimRight(l, " ") + "\n")
+	}
+
+	return fmt.Errorf("%w %s", ErrUnknownField, strings.TrimRight(b.String(), "\n"))
+}
+
+// SuggestFields returns the fields that are similar to the given
+// one, either because they share its prefix or are within
+// SuggestMinDistance edits, closest first.
+func SuggestFields(field string, available []string) []string {
+	type suggestion struct {
+		name string
+		dist int
+	}
+
+	var found []suggestion
+
+	lf := strings.ToLower(field)
+
+	for _, c := range available {
+		lc := strings.ToLower(c)
+		d := levenshtein(lf, lc)
+
+		if d <= SuggestMinDistance || (lf != "" && strings.HasPrefix(lc, lf)) {
+			found = append(found, suggestion{c, d})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].dist < found[j].dist
+	})
+
+	out := make([]string, 0, len(found))
+	for _, s := range found {
+		out = append(out, s.name)
+	}
+
+	return out
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
@@ -0,0 +1,127 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var userColMap = map[string]string{
+	"id":    "The user identifier",
+	"name":  "The user name",
+	"email": "The user email",
+	"age":   "The user age",
+}
+
+func TestResolveFields(t *testing.T) {
+	cases := []struct {
+		name  string
+		given string
+		want  []string
+	}{
+		{"empty request returns default", "", []string{"id", "name"}},
+		{"explicit fields", "email,id", []string{"email", "id"}},
+		{"fields out of the default set", "age", []string{"age"}},
+		{"add to default", "+email", []string{"id", "name", "email"}},
+		{"remove from default", "-id", []string{"name"}},
+		{"add and remove", "+age, -name,+email", []string{"id", "age", "email"}},
+		{"explicit and modifiers", "email,+age,-email", []string{"age"}},
+		{"duplicates are dropped", "id,id,+id", []string{"id"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDisplayable(ctrl)
+
+			m.EXPECT().Cols().AnyTimes().Return([]string{"id", "name"})
+			m.EXPECT().ColMap().AnyTimes().Return(userColMap)
+			m.EXPECT().Filterable().AnyTimes().Return(true)
+
+			got, err := ResolveFields(c.given, m)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestResolveFields_NotFilterable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "name"})
+	m.EXPECT().Filterable().Return(false)
+
+	got, err := ResolveFields("nope", m)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "name"}, got)
+}
+
+func TestResolveFields_UnknownField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "name"})
+	m.EXPECT().ColMap().AnyTimes().Return(userColMap)
+	m.EXPECT().Filterable().Return(true)
+
+	_, err := ResolveFields("nmae,+zzz", m)
+
+	want := `unknown field "nmae", did you mean "name"?
+"zzz"
+
+Available fields:
+  id       The user identifier
+  name     The user name
+  age      The user age
+  email    The user email`
+
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.Equal(t, want, err.Error())
+}
+
+func TestResolveFields_UnknownFieldWithoutDescriptions(t *testing.T) {
+	_, err := ResolveFields("zzz", &staticDisplayable{cols: []string{"id", "name"}})
+
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.Equal(t, "unknown field \"zzz\"\n\nAvailable fields:\n  id\n  name", err.Error())
+}
+
+func TestResolveFields_NoFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "name"})
+	m.EXPECT().ColMap().AnyTimes().Return(userColMap)
+	m.EXPECT().Filterable().Return(true)
+
+	_, err := ResolveFields("-id,-name", m)
+
+	assert.ErrorIs(t, err, ErrNoFields)
+}
+
+func TestSuggestFields(t *testing.T) {
+	available := []string{"name", "namespace", "email", "id"}
+
+	assert.Equal(t, []string{"name", "namespace"}, SuggestFields("nam", available))
+	assert.Equal(t, []string{"email"}, SuggestFields("Emial", available))
+	assert.Empty(t, SuggestFields("status", available))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("name", "name"))
+	assert.Equal(t, 2, levenshtein("nmae", "name"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+	assert.Equal(t, 1, levenshtein("añn", "ann"))
+}
@@ -0,0 +1,239 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultFilePerm is the permission of the files created by
+// FileDisplayer.
+const defaultFilePerm os.FileMode = 0o644
+
+// ErrNotAppendable is returned when appending to a file in a
+// format whose documents can not be concatenated, e.g. json.
+var ErrNotAppendable = errors.New("format does not support appending")
+
+// appendFormats are the formats whose output can be appended to
+// a file keeping it valid.
+var appendFormats = []string{CSVFormat, TSVFormat, JSONLFormat, YAMLFormat}
+
+// fileFormats maps file extensions to output formats.
+var fileFormats = map[string]string{
+	".csv":      CSVFormat,
+	".tsv":      TSVFormat,
+	".json":     JSONFormat,
+	".jsonl":    JSONLFormat,
+	".ndjson":   JSONLFormat,
+	".yaml":     YAMLFormat,
+	".yml":      YAMLFormat,
+	".md":       MarkdownFormat,
+	".markdown": MarkdownFormat,
+	".html":     HTMLFormat,
+	".htm":      HTMLFormat,
+	".txt":      TableFormat,
+}
+
+// FormatForFile returns the output format matching the extension
+// of the path, e.g. csv for report.csv.
+func FormatForFile(path string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	if format, ok := fileFormats[ext]; ok {
+		return format, nil
+	}
+
+	exts := make([]string, 0, len(fileFormats))
+	for e := range fileFormats {
+		exts = append(exts, e)
+	}
+
+	sort.Strings(exts)
+
+	return "", fmt.Errorf(
+		"%w for file %s, possible extensions are %s",
+		ErrUnknownFormat,
+		path,
+		strings.Join(exts, ","),
+	)
+}
+
+// FileConfig customizes how displayables are written to files.
+type FileConfig struct {
+	// Format overrides the format inferred from the file extension.
+	Format string
+	// Append keeps the current content of the file, headers of
+	// delimiter separated values are skipped when the file is
+	// not empty. Only csv, tsv, jsonl and yaml can be appended.
+	Append bool
+	// Displayer builds the displayer of the format writing to
+	// the file, defaults to NewDisplayer. Callers customizing
+	// their outputs, e.g. with exact values, use it to write
+	// files the same way.
+	Displayer func(format string, output io.Writer) (Displayer, error)
+}
+
+type fileDisplayer struct {
+	path   string
+	config FileConfig
+}
+
+// Display writes the displayable to the file.
+func (fd *fileDisplayer) Display(d Displayable, f []string) error {
+	return fd.DisplayMany([]Displayable{d}, f)
+}
+
+// DisplayMany writes every displayable to the file at once, the
+// file is left untouched if any of them fails.
+func (fd *fileDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	dir, name := filepath.Split(fd.path)
+	if dir == "" {
+		dir = "."
+	}
+
+	perm := defaultFilePerm
+
+	current, err := os.Open(fd.path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		defer current.Close()
+
+		info, err := current.Stat()
+		if err != nil {
+			return err
+		}
+
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+
+	// the temporary file is gone once renamed.
+	defer os.Remove(tmp.Name())
+
+	if err := fd.write(tmp, current, ds, f); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fd.path)
+}
+
+func (fd *fileDisplayer) write(tmp *os.File, current *os.File, ds []Displayable, f []string) error {
+	var written int64
+
+	if fd.config.Append && current != nil {
+		n, err := io.Copy(tmp, current)
+		if err != nil {
+			return err
+		}
+
+		written = n
+	}
+
+	dsp, err := fd.config.Displayer(fd.config.Format, tmp)
+	if err != nil {
+		return err
+	}
+
+	if name := formatName(fd.config.Format); written > 0 && (name == CSVFormat || name == TSVFormat) {
+		hidden := make([]Displayable, len(ds))
+		for i, d := range ds {
+			hidden[i] = &headless{Displayable: d}
+		}
+
+		ds = hidden
+	}
+
+	if err := dsp.DisplayMany(ds, f); err != nil {
+		return err
+	}
+
+	return tmp.Sync()
+}
+
+// formatName returns the name of a format without its argument.
+func formatName(format string) string {
+	if i := strings.Index(format, "="); i >= 0 {
+		format = format[:i]
+	}
+
+	return strings.ToLower(format)
+}
+
+// headless is a displayable whose headers are hidden.
+type headless struct {
+	Displayable
+}
+
+// NoHeaders reports whether the headers are hidden.
+func (h *headless) NoHeaders() bool {
+	return true
+}
+
+// Unwrap returns the displayable whose headers are hidden.
+func (h *headless) Unwrap() Displayable {
+	return h.Displayable
+}
+
+// FileDisplayer returns a displayer writing to the file at the
+// provided path, in the format inferred from its extension unless
+// configured otherwise.
+//
+// Files are replaced atomically: the output is written to a
+// temporary file on the same directory which is then renamed,
+// so readers never see partial content. Existing files keep
+// their permissions.
+func FileDisplayer(path string, config FileConfig) (Displayer, error) {
+	if config.Format == "" {
+		format, err := FormatForFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Format = format
+	}
+
+	if config.Displayer == nil {
+		config.Displayer = NewDisplayer
+	}
+
+	// the format is validated before writing anything.
+	if _, err := config.Displayer(config.Format, ioutil.Discard); err != nil {
+		return nil, err
+	}
+
+	if name := formatName(config.Format); config.Append && !contains(appendFormats, name) {
+		return nil, fmt.Errorf(
+			"%w: %s, possible formats are %s",
+			ErrNotAppendable,
+			name,
+			strings.Join(appendFormats, ","),
+		)
+	}
+
+	return &fileDisplayer{path: path, config: config}, nil
+}
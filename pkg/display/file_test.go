@@ -0,0 +1,131 @@
+package display
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatForFile(t *testing.T) {
+	cases := map[string]string{
+		"report.csv":       CSVFormat,
+		"out/REPORT.JSON":  JSONFormat,
+		"events.jsonl":     JSONLFormat,
+		"config.yml":       YAMLFormat,
+		"README.md":        MarkdownFormat,
+		"index.html":       HTMLFormat,
+		"summary.txt":      TableFormat,
+		"archive.tar.yaml": YAMLFormat,
+	}
+
+	for path, want := range cases {
+		got, err := FormatForFile(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got, path)
+	}
+
+	_, err := FormatForFile("report.xlsx")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFileDisplayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.csv")
+	d := &staticDisplayable{rows: currencies[:2], cols: currencyCol}
+
+	dsp, err := FileDisplayer(path, FileConfig{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, dsp.Display(d, nil))
+	assert.Nil(t, dsp.Display(d, []string{"Symbol"}))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "Symbol\nEUR\nUSD\n", string(content))
+
+	info, _ := os.Stat(path)
+	assert.Equal(t, defaultFilePerm, info.Mode().Perm())
+
+	entries, _ := ioutil.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1)
+}
+
+func TestFileDisplayer_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.csv")
+	assert.Nil(t, ioutil.WriteFile(path, nil, 0o600))
+
+	d := &staticDisplayable{rows: currencies[:1], cols: currencyCol}
+
+	dsp, err := FileDisplayer(path, FileConfig{Append: true})
+	assert.Nil(t, err)
+
+	assert.Nil(t, dsp.Display(d, nil))
+	assert.Nil(t, dsp.Display(d, nil))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "Symbol,Quote\nEUR,1\nEUR,1\n", string(content))
+
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	for _, format := range []string{"json", "html", "markdown", "table"} {
+		_, err = FileDisplayer(path, FileConfig{Format: format, Append: true})
+		assert.ErrorIs(t, err, ErrNotAppendable, format)
+	}
+}
+
+func TestFileDisplayer_FormatOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.out")
+	d := &staticDisplayable{rows: currencies[:1], cols: currencyCol}
+
+	_, err := FileDisplayer(path, FileConfig{})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = FileDisplayer(path, FileConfig{Format: "xml"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	dsp, err := FileDisplayer(path, FileConfig{Format: "jsonl"})
+	assert.Nil(t, err)
+	assert.Nil(t, dsp.Display(d, nil))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "{\"Symbol\":\"EUR\",\"Quote\":1}\n", string(content))
+}
+
+func TestFileDisplayer_KeepsFileOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("previous\n"), 0o644))
+
+	dsp, err := FileDisplayer(path, FileConfig{Format: "go-template={{.missing.field}}"})
+	assert.Nil(t, err)
+
+	d := &staticDisplayable{rows: []map[string]interface{}{{"missing": 1}}, cols: []string{"missing"}}
+	assert.Error(t, dsp.Display(d, nil))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "previous\n", string(content))
+}
+
+func TestFileDisplayer_Displayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.json")
+	d := &staticDisplayable{rows: currencies[:1], cols: currencyCol}
+
+	var formats []string
+
+	dsp, err := FileDisplayer(path, FileConfig{
+		Displayer: func(format string, output io.Writer) (Displayer, error) {
+			formats = append(formats, format)
+
+			return JSONRowsDisplayer(output, JSONConfig{APIVersion: "v1", Lines: true}), nil
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, dsp.Display(d, nil))
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "{\"apiVersion\":\"v1\",\"item\":{\"Symbol\":\"EUR\",\"Quote\":1}}\n", string(content))
+	assert.Equal(t, []string{JSONFormat, JSONFormat}, formats)
+}
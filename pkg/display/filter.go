@@ -0,0 +1,473 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidFilter is returned when a filter expression
+// can not be parsed.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter is a compiled row filtering expression.
+//
+// Expressions are built from conditions in the form
+// <column><operator><value> where the operator is one of:
+//
+//	=, ==   equal, values containing * or ? are matched as globs
+//	!=      not equal (or not matching the glob)
+//	>, >=   greater than (or equal)
+//	<, <=   lower than (or equal)
+//	~       matches the regular expression
+//	!~      does not match the regular expression
+//
+// Conditions are combined with ',' or '&&' (AND) and '|' or '||'
+// (OR), AND taking precedence, and can be grouped using
+// parentheses. Values containing spaces or operators can be
+// quoted, e.g. status=active,age>30|name~"^adm".
+type Filter struct {
+	root filterNode
+	cols []string
+}
+
+// ParseFilter compiles a filter expression.
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t != nil {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, t.text)
+	}
+
+	return &Filter{root: root, cols: p.cols}, nil
+}
+
+// Columns returns the columns referenced by the filter.
+func (f *Filter) Columns() []string {
+	return f.cols
+}
+
+// Match reports whether the row satisfies the filter.
+func (f *Filter) Match(row map[string]interface{}) bool {
+	return f.root.match(row)
+}
+
+type filteredDisplayable struct {
+	Displayable
+	filter *Filter
+}
+
+// KV returns the rows of the wrapped displayable matching
+// the filter.
+func (fd *filteredDisplayable) KV() []map[string]interface{} {
+	var rows []map[string]interface{}
+
+	for _, r := range fd.Displayable.KV() {
+		if fd.filter.Match(r) {
+			rows = append(rows, r)
+		}
+	}
+
+	return rows
+}
+
+// Filtered wraps a displayable so only the rows matching the
+// filter are returned.
+//
+// An error listing the available columns is returned when the
+// filter references a column that is not part of the displayable.
+func Filtered(d Displayable, f *Filter) (Displayable, error) {
+	if f == nil {
+		return d, nil
+	}
+
+	for _, col := range f.cols {
+		if err := checkColumn(d, col); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+	}
+
+	return &filteredDisplayable{
+		Displayable: d,
+		filter:      f,
+	}, nil
+}
+
+type filterNode interface {
+	match(row map[string]interface{}) bool
+}
+
+type filterAnd []filterNode
+
+func (fa filterAnd) match(row map[string]interface{}) bool {
+	for _, n := range fa {
+		if !n.match(row) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type filterOr []filterNode
+
+func (fo filterOr) match(row map[string]interface{}) bool {
+	for _, n := range fo {
+		if n.match(row) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type filterCond struct {
+	col   string
+	op    string
+	value string
+	re    *regexp.Regexp
+	glob  bool
+}
+
+func (fc *filterCond) match(row map[string]interface{}) bool {
+	v := row[fc.col]
+
+	switch fc.op {
+	case "=", "==":
+		return fc.equal(v)
+	case "!=":
+		return !fc.equal(v)
+	case "~":
+		return fc.re.MatchString(plainValue(v))
+	case "!~":
+		return !fc.re.MatchString(plainValue(v))
+	}
+
+	if v == nil {
+		return false
+	}
+
+	c := compareValues(v, typedLike(v, fc.value))
+
+	switch fc.op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	default:
+		return c <= 0
+	}
+}
+
+func (fc *filterCond) equal(v interface{}) bool {
+	if fc.glob {
+		return fc.re.MatchString(plainValue(v))
+	}
+
+	if v == nil {
+		return fc.value == ""
+	}
+
+	return compareValues(v, typedLike(v, fc.value)) == 0
+}
+
+// typedLike converts the raw value into the type of the reference
+// value so they can be compared, falling back to the raw string.
+func typedLike(ref interface{}, raw string) interface{} {
+	if _, ok := numeric(ref); ok {
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	}
+
+	switch ref.(type) {
+	case bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case time.Time:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t
+		}
+	}
+
+	return raw
+}
+
+const (
+	tokWord = iota
+	tokOp
+	tokAnd
+	tokOr
+	tokOpen
+	tokClose
+)
+
+type filterToken struct {
+	kind int
+	text string
+}
+
+var filterOps = []string{"==", "!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{tokOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{tokClose, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, filterToken{tokAnd, ","})
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, filterToken{tokAnd, "&&"})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, filterToken{tokOr, "||"})
+			i += 2
+		case c == '|':
+			tokens = append(tokens, filterToken{tokOr, "|"})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote in %q", ErrInvalidFilter, expr)
+			}
+
+			tokens = append(tokens, filterToken{tokWord, expr[i+1 : i+1+end]})
+			i += end + 2
+		default:
+			if op := filterOp(expr[i:]); op != "" {
+				tokens = append(tokens, filterToken{tokOp, op})
+				i += len(op)
+
+				continue
+			}
+
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t(),&|\"'=!<>~", rune(expr[i])) {
+				i++
+			}
+
+			if i == start {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, expr[i:i+1])
+			}
+
+			tokens = append(tokens, filterToken{tokWord, expr[start:i]})
+		}
+	}
+
+	return tokens, nil
+}
+
+func filterOp(s string) string {
+	for _, op := range filterOps {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	cols   []string
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) next() *filterToken {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	var or filterOr
+
+	for {
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		or = append(or, n)
+
+		if t := p.peek(); t == nil || t.kind != tokOr {
+			break
+		}
+
+		p.next()
+	}
+
+	if len(or) == 1 {
+		return or[0], nil
+	}
+
+	return or, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	var and filterAnd
+
+	for {
+		n, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		and = append(and, n)
+
+		if t := p.peek(); t == nil || t.kind != tokAnd {
+			break
+		}
+
+		p.next()
+	}
+
+	if len(and) == 1 {
+		return and[0], nil
+	}
+
+	return and, nil
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+	if t == nil {
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrInvalidFilter)
+	}
+
+	if t.kind == tokOpen {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if c := p.next(); c == nil || c.kind != tokClose {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidFilter)
+		}
+
+		return n, nil
+	}
+
+	if t.kind != tokWord {
+		return nil, fmt.Errorf("%w: expected a column, got %q", ErrInvalidFilter, t.text)
+	}
+
+	op := p.next()
+	if op == nil || op.kind != tokOp {
+		return nil, fmt.Errorf("%w: expected an operator after %q", ErrInvalidFilter, t.text)
+	}
+
+	cond := &filterCond{col: t.text, op: op.text}
+
+	// an empty value is allowed to match nil or empty values.
+	if v := p.peek(); v != nil && v.kind == tokWord {
+		cond.value = p.next().text
+	}
+
+	switch cond.op {
+	case "~", "!~":
+		re, err := regexp.Compile(cond.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+
+		cond.re = re
+	case "=", "==", "!=":
+		if !strings.ContainsAny(cond.value, "*?[") {
+			break
+		}
+
+		re, err := globRegexp(cond.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+
+		cond.re, cond.glob = re, true
+	}
+
+	p.cols = append(p.cols, cond.col)
+
+	return cond, nil
+}
+
+// globRegexp translates a glob into an anchored regular expression.
+// Unlike path.Match its wildcards also match slashes, so values such
+// as URLs can be matched with url=https://*.
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+
+	b.WriteString("(?s)^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			// multi-byte characters are quoted whole.
+			_, size := utf8.DecodeRuneInString(glob[i:])
+
+			b.WriteString(regexp.QuoteMeta(glob[i : i+size]))
+			i += size - 1
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,122 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var accounts = []map[string]interface{}{
+	{"name": "admiral", "status": "active", "age": 31, "admin": true, "since": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "site": "https://admiral.dev"},
+	{"name": "avocatl", "status": "active", "age": 25, "admin": false, "since": time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "site": "https://avocatl.dev"},
+	{"name": "cobra", "status": "disabled", "age": 40, "admin": false, "since": nil, "site": "http://café.dev/cobra"},
+	{"name": "viper", "status": "pending", "age": 12.5, "admin": true, "since": nil, "site": "http://go.dev/viper"},
+}
+
+func TestFilter_Match(t *testing.T) {
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"status=active", []string{"admiral", "avocatl"}},
+		{"status==active,age>30", []string{"admiral"}},
+		{"status=active && age<=25", []string{"avocatl"}},
+		{"status!=active", []string{"cobra", "viper"}},
+		{"age>=25", []string{"admiral", "avocatl", "cobra"}},
+		{"age<13", []string{"viper"}},
+		{"name=a*", []string{"admiral", "avocatl"}},
+		{"name!=a*", []string{"cobra", "viper"}},
+		{"name=?ob[^x]a", []string{"cobra"}},
+		{"site=https://*", []string{"admiral", "avocatl"}},
+		{"site=*/viper", []string{"viper"}},
+		{"site=http://café*", []string{"cobra"}},
+		{"site=*caf?.dev*", []string{"cobra"}},
+		{"name~^[cv]", []string{"cobra", "viper"}},
+		{"name!~o", []string{"admiral", "viper"}},
+		{"admin=true", []string{"admiral", "viper"}},
+		{"since<2020-06-01T00:00:00Z", []string{"admiral"}},
+		{"since=", []string{"cobra", "viper"}},
+		{"status=pending|age>35", []string{"cobra", "viper"}},
+		{"status=disabled || status=active, admin=true", []string{"admiral", "cobra"}},
+		{"(status=disabled | status=active), admin=true", []string{"admiral"}},
+		{"name=\"cob,ra\"", nil},
+		{"name='viper'", []string{"viper"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			f, err := ParseFilter(c.expr)
+			assert.Nil(t, err)
+
+			var got []string
+			for _, r := range accounts {
+				if f.Match(r) {
+					got = append(got, r["name"].(string))
+				}
+			}
+
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"status",
+		"status=active,",
+		"=active",
+		"(status=active",
+		"status=active)",
+		"name~(",
+		"name=\"open",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			_, err := ParseFilter(c)
+
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+		})
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().ColMap().AnyTimes().Return(currencyColMap)
+
+	f, err := ParseFilter("Quote>1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Quote"}, f.Columns())
+
+	d, err := Filtered(m, f)
+	assert.Nil(t, err)
+
+	assert.Equal(t, currencies[1:], d.KV())
+}
+
+func TestFiltered_UnknownColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().ColMap().AnyTimes().Return(currencyColMap)
+
+	f, err := ParseFilter("Rate>1")
+	assert.Nil(t, err)
+
+	_, err = Filtered(m, f)
+
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+	assert.Contains(t, err.Error(), "possible values are Symbol,Quote")
+}
@@ -0,0 +1,116 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Supported output formats.
+const (
+	TableFormat = "table"
+	JSONFormat  = "json"
+	JSONLFormat = "jsonl"
+)
+
+// Format errors.
+var (
+	ErrUnknownFormat    = errors.New("unknown output format")
+	ErrFormatArgument   = errors.New("invalid output format argument")
+	errUnexpectedFormat = fmt.Errorf("%w: format does not accept arguments", ErrFormatArgument)
+)
+
+// FormatFunc constructs a displayer that renders displayables
+// into the provided writer.
+//
+// The argument contains everything following the first '='
+// on the requested format, e.g. the template on
+// go-template={{.name}}, and is empty otherwise.
+type FormatFunc func(output io.Writer, arg string) (Displayer, error)
+
+// SimpleFormat adapts a displayer constructor into a
+// FormatFunc that does not accept arguments.
+func SimpleFormat(fn func(output io.Writer) Displayer) FormatFunc {
+	return func(output io.Writer, arg string) (Displayer, error) {
+		if arg != "" {
+			return nil, errUnexpectedFormat
+		}
+
+		return fn(output), nil
+	}
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]FormatFunc{
+		TableFormat: SimpleFormat(DefaultDisplayer),
+		JSONFormat:  SimpleFormat(JSONDisplayer),
+		JSONLFormat: SimpleFormat(JSONLDisplayer),
+	}
+)
+
+// RegisterFormat makes an output format available by name,
+// replacing any format previously registered with the same
+// name.
+func RegisterFormat(name string, fn FormatFunc) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[strings.ToLower(name)] = fn
+}
+
+// Formats returns the names of the registered output
+// formats sorted alphabetically.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewDisplayer returns a displayer for the requested format
+// writing to the provided output (defaults to os.Stdout).
+//
+// Formats accepting an argument are requested as name=arg,
+// e.g. jsonpath={.name}. An empty format falls back to the
+// table format.
+func NewDisplayer(format string, output io.Writer) (Displayer, error) {
+	if format == "" {
+		format = TableFormat
+	}
+
+	name, arg := format, ""
+	if i := strings.Index(format, "="); i >= 0 {
+		name, arg = format[:i], format[i+1:]
+	}
+
+	formatsMu.RLock()
+	fn, ok := formats[strings.ToLower(name)]
+	formatsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w %q, possible values are %s",
+			ErrUnknownFormat,
+			name,
+			strings.Join(Formats(), ","),
+		)
+	}
+
+	dsp, err := fn(stdout(output), arg)
+	if err != nil {
+		return nil, fmt.Errorf("output format %s: %w", name, err)
+	}
+
+	return dsp, nil
+}
@@ -0,0 +1,93 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormats(t *testing.T) {
+	got := Formats()
+
+	assert.Contains(t, got, TableFormat)
+	assert.Contains(t, got, JSONFormat)
+	assert.Contains(t, got, JSONLFormat)
+	assert.Contains(t, got, YAMLFormat)
+	assert.Contains(t, got, CSVFormat)
+	assert.Contains(t, got, TSVFormat)
+	assert.Contains(t, got, DescribeFormat)
+}
+
+func TestNewDisplayer(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		want   Displayer
+	}{
+		{
+			"empty format defaults to table",
+			"",
+			&stdDisplayer{},
+		},
+		{
+			"table format",
+			"table",
+			&stdDisplayer{},
+		},
+		{
+			"format names are case insensitive",
+			"JSON",
+			&jsonRowsDisplayer{},
+		},
+		{
+			"jsonl format",
+			"jsonl",
+			&jsonRowsDisplayer{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := NewDisplayer(c.format, nil)
+
+			assert.Nil(t, err)
+			assert.IsType(t, c.want, got)
+		})
+	}
+}
+
+func TestNewDisplayer_UnknownFormat(t *testing.T) {
+	_, err := NewDisplayer("xml", nil)
+
+	assert.True(t, errors.Is(err, ErrUnknownFormat))
+	assert.Contains(t, err.Error(), "table")
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("custom", SimpleFormat(func(output io.Writer) Displayer {
+		return DefaultDisplayer(output)
+	}))
+
+	defer func() {
+		formatsMu.Lock()
+		delete(formats, "custom")
+		formatsMu.Unlock()
+	}()
+
+	b := bytes.NewBufferString("")
+
+	got, err := NewDisplayer("custom", b)
+
+	assert.Nil(t, err)
+	assert.Contains(t, Formats(), "custom")
+	assert.NotNil(t, got)
+}
+
+func TestNewDisplayer_UnexpectedArgument(t *testing.T) {
+	_, err := NewDisplayer("json=pretty", nil)
+
+	assert.True(t, errors.Is(err, ErrFormatArgument))
+}
@@ -0,0 +1,100 @@
+package display
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLFormat renders displayables as a standalone HTML
+// document.
+const HTMLFormat = "html"
+
+func init() {
+	RegisterFormat(HTMLFormat, SimpleFormat(HTMLDisplayer))
+}
+
+const (
+	htmlHeader = "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n</head>\n<body>\n"
+	htmlFooter = "</body>\n</html>\n"
+)
+
+type htmlDisplayer struct {
+	output io.Writer
+}
+
+// Display writes a standalone HTML document containing a
+// table with the selected columns of every row.
+func (hd *htmlDisplayer) Display(d Displayable, f []string) error {
+	return hd.DisplayMany([]Displayable{d}, f)
+}
+
+// DisplayMany writes a single HTML document containing a
+// table for each displayable.
+func (hd *htmlDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	w := bufio.NewWriter(hd.output)
+
+	_, _ = w.WriteString(htmlHeader)
+
+	for _, d := range ds {
+		writeHTMLTable(w, d, getCols(d, f))
+	}
+
+	_, _ = w.WriteString(htmlFooter)
+
+	return w.Flush()
+}
+
+func (hd *htmlDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *hd
+	t.output = wrap(hd.output)
+
+	return &t
+}
+
+func writeHTMLTable(w *bufio.Writer, d Displayable, cols []string) {
+	_, _ = w.WriteString("<table>\n")
+
+	if !d.NoHeaders() {
+		_, _ = w.WriteString("<thead>\n")
+		writeHTMLRow(w, "th", cols)
+		_, _ = w.WriteString("</thead>\n")
+	}
+
+	_, _ = w.WriteString("<tbody>\n")
+
+	record := make([]string, len(cols))
+
+	for _, r := range d.KV() {
+		for i, col := range cols {
+			record[i] = plainValue(r[col])
+		}
+
+		writeHTMLRow(w, "td", record)
+	}
+
+	_, _ = w.WriteString("</tbody>\n</table>\n")
+}
+
+func writeHTMLRow(w *bufio.Writer, cell string, values []string) {
+	_, _ = w.WriteString("<tr>")
+
+	for _, v := range values {
+		_, _ = w.WriteString("<" + cell + ">")
+		_, _ = w.WriteString(strings.ReplaceAll(html.EscapeString(v), "\n", "<br>"))
+		_, _ = w.WriteString("</" + cell + ">")
+	}
+
+	_, _ = w.WriteString("</tr>\n")
+}
+
+// HTMLDisplayer renders displayables as a standalone HTML
+// document to the provided writer (defaults to os.Stdout).
+//
+// Values are escaped so they are always rendered as text.
+func HTMLDisplayer(output io.Writer) Displayer {
+	return &htmlDisplayer{
+		output: stdout(output),
+	}
+}
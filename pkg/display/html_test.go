@@ -0,0 +1,56 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_HTMLDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(snippets)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "code", "html"})
+	m.EXPECT().NoHeaders().Return(false)
+	m.EXPECT().Filterable().Return(true)
+
+	want := htmlHeader +
+		"<table>\n" +
+		"<thead>\n<tr><th>html</th><th>id</th></tr>\n</thead>\n" +
+		"<tbody>\n" +
+		"<tr><td>&lt;b&gt;&amp;&lt;/b&gt;</td><td>1</td></tr>\n" +
+		"<tr><td>two<br>lines</td><td>2</td></tr>\n" +
+		"</tbody>\n</table>\n" +
+		htmlFooter
+
+	b := bytes.NewBufferString("")
+
+	err := HTMLDisplayer(b).Display(m, []string{"html", "id"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_HTMLDisplayer_NoHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies[:1])
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().NoHeaders().Return(true)
+
+	b := bytes.NewBufferString("")
+
+	err := HTMLDisplayer(b).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, b.String(), "<thead>")
+	assert.Contains(t, b.String(), "<tr><td>EUR</td><td>1</td></tr>\n")
+}
@@ -0,0 +1,601 @@
+package display
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Escape sequences used by the interactive viewer.
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	hideCursor   = "\x1b[?25l"
+	showCursor   = "\x1b[?25h"
+)
+
+// maxInteractiveColWidth is the widest a column is rendered
+// by the interactive viewer.
+const maxInteractiveColWidth = 40
+
+// lineBreakMark replaces the line breaks of multi-line values,
+// every row is displayed on a single line of the terminal.
+const lineBreakMark = "↵"
+
+const interactiveHelp = "↑↓←→ move  / search  n/N next/prev  s sort  h hide  H show all  y copy cell  Y copy row  q quit"
+
+// InteractiveConfig customizes the interactive viewer.
+type InteractiveConfig struct {
+	// Input to read the keys from, defaults to os.Stdin.
+	Input io.Reader
+	// Exact displays exact values instead of humanized ones.
+	Exact bool
+	// Fallback displays the rows when not running on a terminal,
+	// defaults to the table.
+	Fallback Displayer
+}
+
+type interactiveDisplayer struct {
+	output   io.Writer
+	input    io.Reader
+	exact    bool
+	fallback Displayer
+}
+
+// Display opens a full screen viewer of the displayable rows,
+// the cell or row copied when leaving is written to the output.
+//
+// When either the input or the output is not a terminal the
+// rows are displayed by the fallback displayer instead.
+func (id *interactiveDisplayer) Display(d Displayable, f []string) error {
+	in, ok := id.input.(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) || !isTerminal(id.output) {
+		return id.fallback.Display(d, f)
+	}
+
+	cols := getCols(d, f)
+	if len(cols) == 0 {
+		return nil
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+
+	v := newViewer(d, cols, humanized(id.exact))
+
+	_, err = io.WriteString(id.output, altScreenOn+hideCursor)
+	if err == nil {
+		err = v.run(bufio.NewReader(in), id.output, func() (int, int) {
+			w, h, _ := term.GetSize(int(id.output.(*os.File).Fd()))
+
+			return w, h
+		})
+	}
+
+	_, _ = io.WriteString(id.output, showCursor+altScreenOff)
+
+	if rerr := term.Restore(int(in.Fd()), state); err == nil {
+		err = rerr
+	}
+
+	if err != nil || v.copied == "" {
+		return err
+	}
+
+	_, err = io.WriteString(id.output, v.copied+"\n")
+
+	return err
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (id *interactiveDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(id, ds, f)
+}
+
+func (id *interactiveDisplayer) exactCopy() Displayer {
+	t := *id
+	t.exact = true
+	t.fallback = ExactDisplayer(id.fallback)
+
+	return &t
+}
+
+// InteractiveDisplayer renders displayables in a full screen,
+// scrollable table to the provided writer (defaults to
+// os.Stdout), falling back to the configured displayer when
+// not running on a terminal.
+//
+// Rows are navigated with the arrow keys, searched
+// incrementally with '/', sorted by the current column with
+// 's' and columns hidden with 'h'. Leaving with 'y' or 'Y'
+// copies the current cell or row to the output.
+func InteractiveDisplayer(output io.Writer, config InteractiveConfig) Displayer {
+	output = stdout(output)
+
+	if config.Input == nil {
+		config.Input = os.Stdin
+	}
+
+	if config.Fallback == nil {
+		config.Fallback = TableDisplayer(output, TableConfig{
+			Width: TerminalWidth(output),
+			Exact: config.Exact,
+		})
+	}
+
+	return &interactiveDisplayer{
+		output:   output,
+		input:    config.Input,
+		exact:    config.Exact,
+		fallback: config.Fallback,
+	}
+}
+
+type viewerRow struct {
+	values map[string]interface{}
+	cells  []string
+}
+
+// viewer holds the state of the interactive table.
+type viewer struct {
+	cols   []string
+	colMap map[string]string
+	rows   []viewerRow
+	hidden map[int]bool
+
+	row, col  int
+	top, left int
+	page      int
+
+	sortCol  int
+	sortDesc bool
+	sorted   bool
+
+	searching bool
+	query     string
+	from      int
+
+	copied string
+}
+
+func newViewer(d Displayable, cols []string, human bool) *viewer {
+	v := &viewer{
+		cols:   cols,
+		colMap: d.ColMap(),
+		hidden: map[int]bool{},
+		page:   1,
+	}
+
+	for _, r := range d.KV() {
+		cells := make([]string, len(cols))
+		for i, col := range cols {
+			cells[i] = formatValue(col, r[col], human)
+		}
+
+		v.rows = append(v.rows, viewerRow{values: r, cells: cells})
+	}
+
+	return v
+}
+
+// run renders the table and handles keys until the viewer is
+// closed or the input ends.
+func (v *viewer) run(in *bufio.Reader, out io.Writer, size func() (int, int)) error {
+	for {
+		width, height := size()
+
+		if _, err := io.WriteString(out, v.render(width, height)); err != nil {
+			return err
+		}
+
+		k, err := readKey(in)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if v.handle(k) {
+			return nil
+		}
+	}
+}
+
+// readKey returns the name of the special keys, e.g. "up", or
+// the typed character.
+func readKey(in *bufio.Reader) (string, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return "", err
+	}
+
+	switch r {
+	case 3:
+		return "ctrl-c", nil
+	case '\r', '\n':
+		return "enter", nil
+	case 127, 8:
+		return "backspace", nil
+	case 27:
+	default:
+		return string(r), nil
+	}
+
+	// a lone escape is not followed by a sequence.
+	if in.Buffered() == 0 {
+		return "esc", nil
+	}
+
+	if next, _ := in.Peek(1); next[0] != '[' && next[0] != 'O' {
+		return "esc", nil
+	}
+
+	_, _ = in.ReadByte()
+
+	var seq strings.Builder
+
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		seq.WriteByte(b)
+
+		if b >= '@' && b <= '~' {
+			break
+		}
+	}
+
+	switch seq.String() {
+	case "A":
+		return "up", nil
+	case "B":
+		return "down", nil
+	case "C":
+		return "right", nil
+	case "D":
+		return "left", nil
+	case "H", "1~":
+		return "home", nil
+	case "F", "4~":
+		return "end", nil
+	case "5~":
+		return "pgup", nil
+	case "6~":
+		return "pgdown", nil
+	default:
+		return "", nil
+	}
+}
+
+// handle updates the state for the key, reporting whether the
+// viewer should be closed.
+func (v *viewer) handle(k string) bool {
+	if v.searching {
+		v.handleSearch(k)
+
+		return false
+	}
+
+	switch k {
+	case "q", "esc", "ctrl-c":
+		return true
+	case "up", "k":
+		v.row--
+	case "down", "j":
+		v.row++
+	case "pgup":
+		v.row -= v.page
+	case "pgdown", " ":
+		v.row += v.page
+	case "home", "g":
+		v.row = 0
+	case "end", "G":
+		v.row = len(v.rows) - 1
+	case "left":
+		v.col = v.nextCol(-1)
+	case "right":
+		v.col = v.nextCol(1)
+	case "/":
+		v.searching, v.query, v.from = true, "", v.row
+	case "n":
+		v.search(v.row+1, 1)
+	case "N":
+		v.search(v.row-1, -1)
+	case "s":
+		v.sort()
+	case "h":
+		v.hide()
+	case "H":
+		v.hidden = map[int]bool{}
+	case "y", "Y":
+		if len(v.rows) == 0 {
+			return true
+		}
+
+		v.copied = v.rows[v.row].cells[v.col]
+
+		if k == "Y" {
+			var cells []string
+
+			for _, c := range v.visibleCols() {
+				cells = append(cells, v.rows[v.row].cells[c])
+			}
+
+			v.copied = strings.Join(cells, "\t")
+		}
+
+		return true
+	}
+
+	v.clamp()
+
+	return false
+}
+
+func (v *viewer) handleSearch(k string) {
+	switch k {
+	case "enter":
+		v.searching = false
+	case "esc", "ctrl-c":
+		v.searching, v.query, v.row = false, "", v.from
+	case "backspace":
+		if r := []rune(v.query); len(r) > 0 {
+			v.query = string(r[:len(r)-1])
+		}
+
+		v.row = v.from
+		v.search(v.from, 1)
+	default:
+		if len([]rune(k)) != 1 {
+			return
+		}
+
+		v.query += k
+		v.search(v.from, 1)
+	}
+
+	v.clamp()
+}
+
+// search moves to the first row matching the query starting at
+// the given row in the given direction, wrapping around.
+func (v *viewer) search(start, step int) {
+	if v.query == "" || len(v.rows) == 0 {
+		return
+	}
+
+	query := strings.ToLower(v.query)
+
+	for n := 0; n < len(v.rows); n++ {
+		i := ((start+n*step)%len(v.rows) + len(v.rows)) % len(v.rows)
+
+		for _, c := range v.visibleCols() {
+			if strings.Contains(strings.ToLower(v.rows[i].cells[c]), query) {
+				v.row = i
+
+				return
+			}
+		}
+	}
+}
+
+// sort orders the rows by the current column, toggling the
+// direction when it was already sorted by it.
+func (v *viewer) sort() {
+	v.sortDesc = v.sorted && v.sortCol == v.col && !v.sortDesc
+	v.sortCol, v.sorted = v.col, true
+
+	col := v.cols[v.col]
+
+	sort.SliceStable(v.rows, func(i, j int) bool {
+		c := compareValues(v.rows[i].values[col], v.rows[j].values[col])
+		if v.sortDesc {
+			return c > 0
+		}
+
+		return c < 0
+	})
+}
+
+// hide hides the current column unless it is the last visible.
+func (v *viewer) hide() {
+	if len(v.visibleCols()) <= 1 {
+		return
+	}
+
+	v.hidden[v.col] = true
+
+	if next := v.nextCol(1); next != v.col {
+		v.col = next
+	} else {
+		v.col = v.nextCol(-1)
+	}
+}
+
+func (v *viewer) visibleCols() []int {
+	var cols []int
+
+	for i := range v.cols {
+		if !v.hidden[i] {
+			cols = append(cols, i)
+		}
+	}
+
+	return cols
+}
+
+// nextCol returns the closest visible column in the direction,
+// or the current one when there is none.
+func (v *viewer) nextCol(step int) int {
+	for i := v.col + step; i >= 0 && i < len(v.cols); i += step {
+		if !v.hidden[i] {
+			return i
+		}
+	}
+
+	return v.col
+}
+
+func (v *viewer) clamp() {
+	if v.row >= len(v.rows) {
+		v.row = len(v.rows) - 1
+	}
+
+	if v.row < 0 {
+		v.row = 0
+	}
+}
+
+// render returns the frame for a screen of the given size.
+// singleLine replaces the line breaks of the value with a mark,
+// so they do not break the frame written in raw mode.
+func singleLine(s string) string {
+	return strings.NewReplacer("\r\n", lineBreakMark, "\n", lineBreakMark).Replace(s)
+}
+
+func (v *viewer) render(width, height int) string {
+	// the header and the status line are always displayed.
+	v.page = height - 2
+	if v.page < 1 {
+		v.page = 1
+	}
+
+	switch {
+	case v.row < v.top:
+		v.top = v.row
+	case v.row >= v.top+v.page:
+		v.top = v.row - v.page + 1
+	}
+
+	cols := v.visibleCols()
+	headers := make([]string, len(v.cols))
+	widths := make([]int, len(v.cols))
+
+	for _, c := range cols {
+		headers[c] = v.cols[c]
+
+		if v.sorted && v.sortCol == c {
+			headers[c] += map[bool]string{false: " ▲", true: " ▼"}[v.sortDesc]
+		}
+
+		widths[c] = StringWidth(headers[c])
+
+		for _, r := range v.rows {
+			if w := StringWidth(singleLine(r.cells[c])); w > widths[c] {
+				widths[c] = w
+			}
+		}
+
+		if widths[c] > maxInteractiveColWidth {
+			widths[c] = maxInteractiveColWidth
+		}
+	}
+
+	cols = v.scroll(cols, widths, width)
+
+	var b strings.Builder
+
+	// lines are overwritten in place to avoid flickering.
+	b.WriteString(cursorHome)
+
+	line := func(cells []string, style func(c int) Style) {
+		var l strings.Builder
+
+		used := 0
+
+		for i, c := range cols {
+			text := Truncate(singleLine(cells[c]), widths[c])
+			if i < len(cols)-1 {
+				text += padding(widths[c] - StringWidth(text) + 2)
+			}
+
+			if used+StringWidth(text) > width {
+				text = Truncate(text, width-used)
+			}
+
+			used += StringWidth(text)
+			l.WriteString(style(c).Render(text))
+		}
+
+		b.WriteString(l.String() + "\x1b[K\r\n")
+	}
+
+	line(headers, func(int) Style { return Style{Bold: true} })
+
+	for i := v.top; i < len(v.rows) && i < v.top+v.page; i++ {
+		current := i == v.row
+
+		line(v.rows[i].cells, func(c int) Style {
+			return Style{Reverse: current, Underline: current && c == v.col}
+		})
+	}
+
+	b.WriteString(clearBelow)
+	b.WriteString(Style{Faint: !v.searching}.Render(Truncate(v.status(), width)))
+
+	return b.String()
+}
+
+// scroll returns the columns fitting the width, starting from the
+// left most column shown before and keeping the current one.
+func (v *viewer) scroll(cols []int, widths []int, width int) []int {
+	pos := 0
+
+	for i, c := range cols {
+		if c == v.col {
+			pos = i
+		}
+	}
+
+	if v.left > pos {
+		v.left = pos
+	}
+
+	fits := func(from, to int) bool {
+		total := 0
+		for _, c := range cols[from : to+1] {
+			total += widths[c] + 2
+		}
+
+		return total-2 <= width
+	}
+
+	for v.left < pos && !fits(v.left, pos) {
+		v.left++
+	}
+
+	if v.left >= len(cols) {
+		v.left = 0
+	}
+
+	return cols[v.left:]
+}
+
+func (v *viewer) status() string {
+	if v.searching {
+		return "/" + v.query
+	}
+
+	if len(v.rows) == 0 {
+		return "no rows  " + interactiveHelp
+	}
+
+	col := v.cols[v.col]
+	if d := v.colMap[col]; d != "" {
+		col += ": " + d
+	}
+
+	return fmt.Sprintf("%d/%d  %s  %s", v.row+1, len(v.rows), col, interactiveHelp)
+}
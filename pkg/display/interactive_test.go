@@ -0,0 +1,121 @@
+package display
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runViewer(t *testing.T, keys string) (*viewer, string) {
+	t.Helper()
+
+	v := newViewer(&staticDisplayable{rows: currencies, cols: currencyCol}, currencyCol, true)
+	b := bytes.NewBufferString("")
+
+	err := v.run(bufio.NewReader(strings.NewReader(keys)), b, func() (int, int) { return 40, 10 })
+	assert.Nil(t, err)
+
+	return v, b.String()
+}
+
+func TestReadKey(t *testing.T) {
+	in := bufio.NewReader(strings.NewReader("\x1b[A\x1b[B\x1b[C\x1b[D\x1b[5~\x1b[6~\x1bOHq\r\x7f\x03é"))
+
+	var keys []string
+
+	for {
+		k, err := readKey(in)
+		if err != nil {
+			break
+		}
+
+		keys = append(keys, k)
+	}
+
+	assert.Equal(t, []string{"up", "down", "right", "left", "pgup", "pgdown", "home", "q", "enter", "backspace", "ctrl-c", "é"}, keys)
+}
+
+func TestViewer_CopyCell(t *testing.T) {
+	v, _ := runViewer(t, "\x1b[B\x1b[B\x1b[By")
+
+	assert.Equal(t, "MXN", v.copied)
+
+	v, _ = runViewer(t, "j\x1b[CY")
+
+	assert.Equal(t, "USD\t1.22", v.copied)
+
+	v, _ = runViewer(t, "jq")
+
+	assert.Equal(t, "", v.copied)
+}
+
+func TestViewer_Search(t *testing.T) {
+	v, _ := runViewer(t, "/mx\ry")
+	assert.Equal(t, "MXN", v.copied)
+
+	v, _ = runViewer(t, "/u\x7f\x7fs\rny")
+	assert.Equal(t, "USD", v.copied)
+
+	v, _ = runViewer(t, "j/mx\x1by")
+	assert.Equal(t, "USD", v.copied)
+}
+
+func TestViewer_SortAndHide(t *testing.T) {
+	v, _ := runViewer(t, "\x1b[Css\x1b[Dy")
+	assert.Equal(t, "MXN", v.copied)
+
+	v, _ = runViewer(t, "hY")
+	assert.Equal(t, "1", v.copied)
+
+	v, _ = runViewer(t, "hhHY")
+	assert.Equal(t, "EUR\t1", v.copied)
+}
+
+func TestViewer_Render(t *testing.T) {
+	_, out := runViewer(t, "\x1b[Csq")
+
+	frames := strings.Split(out, cursorHome)
+	last := frames[len(frames)-1]
+
+	assert.Contains(t, last, "\x1b[1mSymbol  \x1b[0m\x1b[1mQuote ▲\x1b[0m")
+	assert.Contains(t, last, "\x1b[7mEUR     \x1b[0m\x1b[4;7m1\x1b[0m")
+	assert.Contains(t, last, "1/3  Quote")
+}
+
+func TestViewer_RenderMultiLine(t *testing.T) {
+	d := &staticDisplayable{
+		rows: []map[string]interface{}{{"Symbol": "EUR", "Quote": "one\ntwo"}},
+		cols: currencyCol,
+	}
+
+	v := newViewer(d, currencyCol, true)
+	frame := v.render(40, 10)
+
+	assert.Contains(t, frame, "one↵two")
+	// the header and the row are the only lines of the frame.
+	assert.Equal(t, 2, strings.Count(frame, "\n"))
+	assert.Equal(t, 2, strings.Count(frame, "\r\n"))
+}
+
+func TestInteractiveDisplayer_NotATerminal(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	err := InteractiveDisplayer(b, InteractiveConfig{Input: strings.NewReader("q")}).
+		Display(&staticDisplayable{rows: currencies[:1], cols: currencyCol}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Symbol    Quote\nEUR       1\n", b.String())
+}
+
+func TestInteractiveDisplayer_Fallback(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	err := InteractiveDisplayer(b, InteractiveConfig{Input: strings.NewReader("q"), Fallback: CSVDisplayer(b)}).
+		Display(&staticDisplayable{rows: currencies[:1], cols: currencyCol}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Symbol,Quote\nEUR,1\n", b.String())
+}
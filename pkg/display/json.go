@@ -0,0 +1,169 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// JSONConfig customizes the JSON and JSON Lines outputs.
+type JSONConfig struct {
+	// Lines writes every row as a single line object instead
+	// of an indented array.
+	Lines bool
+	// APIVersion wraps the rows in a versioned envelope, e.g.
+	// {"apiVersion": "v1", "items": [...]}, giving scripts a
+	// stable contract across releases. JSON Lines write the
+	// envelope on every line with the row under "item".
+	APIVersion string
+}
+
+type jsonRowsDisplayer struct {
+	output io.Writer
+	config JSONConfig
+}
+
+// Display renders the selected columns of every row
+// as JSON objects keeping the column order.
+func (jd *jsonRowsDisplayer) Display(d Displayable, f []string) error {
+	enc := json.NewEncoder(jd.output)
+	enc.SetEscapeHTML(false)
+
+	if !jd.config.Lines {
+		enc.SetIndent("", "    ")
+	}
+
+	// raw objects wrapped with JSON are encoded as they are.
+	if raw, ok := rawJSON(d); ok {
+		return enc.Encode(raw.Data)
+	}
+
+	rows := selectRows(d, getCols(d, f))
+	version := jd.config.APIVersion
+
+	if !jd.config.Lines {
+		if version != "" {
+			return enc.Encode(jsonList{APIVersion: version, Items: rows})
+		}
+
+		return enc.Encode(rows)
+	}
+
+	for _, r := range rows {
+		var v interface{} = r
+		if version != "" {
+			v = jsonItem{APIVersion: version, Item: r}
+		}
+
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (jd *jsonRowsDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(jd, ds, f)
+}
+
+func (jd *jsonRowsDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *jd
+	t.output = wrap(jd.output)
+
+	return &t
+}
+
+type jsonList struct {
+	APIVersion string       `json:"apiVersion"`
+	Items      []orderedRow `json:"items"`
+}
+
+type jsonItem struct {
+	APIVersion string     `json:"apiVersion"`
+	Item       orderedRow `json:"item"`
+}
+
+// JSONDisplayer renders the rows of a displayable as an
+// indented JSON array to the provided writer (defaults to
+// os.Stdout).
+//
+// Only the selected columns are included on each row, in
+// column order, displayables created with JSON are encoded
+// as is.
+func JSONDisplayer(output io.Writer) Displayer {
+	return JSONRowsDisplayer(output, JSONConfig{})
+}
+
+// JSONLDisplayer renders each row of a displayable as a
+// single line JSON object (JSON Lines) to the provided
+// writer (defaults to os.Stdout).
+func JSONLDisplayer(output io.Writer) Displayer {
+	return JSONRowsDisplayer(output, JSONConfig{Lines: true})
+}
+
+// JSONRowsDisplayer renders the rows of a displayable as JSON
+// to the provided writer (defaults to os.Stdout) using the
+// given configuration.
+func JSONRowsDisplayer(output io.Writer, config JSONConfig) Displayer {
+	return &jsonRowsDisplayer{
+		output: stdout(output),
+		config: config,
+	}
+}
+
+// orderedRow is encoded as a JSON object with its keys in
+// column order.
+type orderedRow struct {
+	cols   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r orderedRow) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	b.WriteString("{")
+
+	for i, col := range r.cols {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		if err := enc.Encode(col); err != nil {
+			return nil, err
+		}
+
+		b.WriteString(":")
+
+		if err := enc.Encode(r.values[col]); err != nil {
+			return nil, err
+		}
+	}
+
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
+
+func selectRows(d Displayable, cols []string) []orderedRow {
+	kv := d.KV()
+	rows := make([]orderedRow, 0, len(kv))
+
+	for _, r := range kv {
+		row := make(map[string]interface{}, len(cols))
+
+		for _, col := range cols {
+			row[col] = nestedRows(r[col])
+		}
+
+		rows = append(rows, orderedRow{cols: cols, values: row})
+	}
+
+	return rows
+}
@@ -0,0 +1,128 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_JSONDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+
+	want := `[
+    {
+        "Symbol": "EUR",
+        "Quote": 1
+    },
+    {
+        "Symbol": "USD",
+        "Quote": 1.22
+    },
+    {
+        "Symbol": "MXN",
+        "Quote": 24.45
+    }
+]
+`
+
+	b := bytes.NewBufferString("")
+
+	err := JSONDisplayer(b).Display(m, []string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_JSONLDisplayer_Filtered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().Filterable().Return(true)
+
+	want := "{\"Symbol\":\"EUR\"}\n{\"Symbol\":\"USD\"}\n{\"Symbol\":\"MXN\"}\n"
+
+	b := bytes.NewBufferString("")
+
+	err := JSONLDisplayer(b).Display(m, []string{"Symbol"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_JSONDisplayer_RawData(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	err := JSONLDisplayer(b).Display(JSON(test{Text: "<hi>"}, true), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"message\":\"<hi>\"}\n", b.String())
+}
+
+func TestDisplay_JSONRowsDisplayer_Envelope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().AnyTimes().Return(currencies[:2])
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().Filterable().AnyTimes().Return(true)
+
+	b := bytes.NewBufferString("")
+
+	err := JSONRowsDisplayer(b, JSONConfig{APIVersion: "admiral/v1"}).Display(m, []string{"Quote", "Symbol"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+    "apiVersion": "admiral/v1",
+    "items": [
+        {
+            "Quote": 1,
+            "Symbol": "EUR"
+        },
+        {
+            "Quote": 1.22,
+            "Symbol": "USD"
+        }
+    ]
+}
+`, b.String())
+
+	b.Reset()
+
+	err = JSONRowsDisplayer(b, JSONConfig{APIVersion: "admiral/v1", Lines: true}).Display(m, []string{"Symbol"})
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		`{"apiVersion":"admiral/v1","item":{"Symbol":"EUR"}}`+"\n"+
+			`{"apiVersion":"admiral/v1","item":{"Symbol":"USD"}}`+"\n",
+		b.String(),
+	)
+}
+
+func TestDisplay_JSONRowsDisplayer_EmptyEnvelope(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	d := &staticDisplayable{cols: currencyCol}
+
+	err := JSONRowsDisplayer(b, JSONConfig{APIVersion: "v1", Lines: true}).Display(d, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "", b.String())
+
+	err = JSONRowsDisplayer(b, JSONConfig{APIVersion: "v1"}).Display(d, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n    \"apiVersion\": \"v1\",\n    \"items\": []\n}\n", b.String())
+}
@@ -0,0 +1,311 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	jpText = iota
+	jpPath
+	jpRange
+)
+
+const (
+	segName = iota
+	segIndex
+	segWildcard
+)
+
+type jpSegment struct {
+	kind  int
+	name  string
+	index int
+}
+
+type jpNode struct {
+	kind int
+	text string
+	path []jpSegment
+	body []jpNode
+}
+
+// jsonPath is a subset of the kubectl JSONPath template syntax
+// supporting fields, indexes, wildcards, string literals and
+// range blocks.
+type jsonPath struct {
+	nodes []jpNode
+}
+
+func parseJSONPath(text string) (*jsonPath, error) {
+	nodes, _, closed, err := parseJSONPathNodes(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if closed {
+		return nil, fmt.Errorf("%w: unexpected {end}", ErrFormatArgument)
+	}
+
+	return &jsonPath{nodes: nodes}, nil
+}
+
+// parseJSONPathNodes parses until the end of the text or until
+// an {end} action, in which case closed is set and the unparsed
+// remainder is returned.
+func parseJSONPathNodes(text string) (nodes []jpNode, rest string, closed bool, err error) {
+	for text != "" {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			nodes = append(nodes, jpNode{kind: jpText, text: text})
+
+			break
+		}
+
+		if open > 0 {
+			nodes = append(nodes, jpNode{kind: jpText, text: text[:open]})
+		}
+
+		end := closingBrace(text, open)
+		if end < 0 {
+			return nil, "", false, fmt.Errorf("%w: unclosed action in %q", ErrFormatArgument, text)
+		}
+
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			return nodes, text, true, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parseJSONPathExpr(strings.TrimSpace(action[len("range "):]))
+			if err != nil {
+				return nil, "", false, err
+			}
+
+			body, rest, closed, err := parseJSONPathNodes(text)
+			if err != nil {
+				return nil, "", false, err
+			}
+
+			if !closed {
+				return nil, "", false, fmt.Errorf("%w: range without {end}", ErrFormatArgument)
+			}
+
+			nodes = append(nodes, jpNode{kind: jpRange, path: path, body: body})
+			text = rest
+		case strings.HasPrefix(action, `"`):
+			s, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, "", false, fmt.Errorf("%w: invalid string literal %s", ErrFormatArgument, action)
+			}
+
+			nodes = append(nodes, jpNode{kind: jpText, text: s})
+		default:
+			path, err := parseJSONPathExpr(action)
+			if err != nil {
+				return nil, "", false, err
+			}
+
+			nodes = append(nodes, jpNode{kind: jpPath, path: path})
+		}
+	}
+
+	return nodes, "", false, nil
+}
+
+// closingBrace finds the brace closing the action opened at
+// position open, ignoring braces inside string literals.
+func closingBrace(text string, open int) int {
+	quoted := false
+
+	for i := open + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case '}':
+			if !quoted {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func parseJSONPathExpr(expr string) ([]jpSegment, error) {
+	invalid := func() error {
+		return fmt.Errorf("%w: invalid path %q", ErrFormatArgument, expr)
+	}
+
+	p := strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+
+	var segs []jpSegment
+
+	for p != "" {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			if strings.HasPrefix(p, ".") {
+				return nil, invalid()
+			}
+
+			n := strings.IndexAny(p, ".[")
+			if n < 0 {
+				n = len(p)
+			}
+
+			name := p[:n]
+			p = p[n:]
+
+			switch name {
+			case "":
+				continue
+			case "*":
+				segs = append(segs, jpSegment{kind: segWildcard})
+			default:
+				segs = append(segs, jpSegment{kind: segName, name: name})
+			}
+		case '[':
+			n := strings.IndexByte(p, ']')
+			if n < 0 {
+				return nil, invalid()
+			}
+
+			sub := strings.TrimSpace(p[1:n])
+			p = p[n+1:]
+
+			switch {
+			case sub == "*":
+				segs = append(segs, jpSegment{kind: segWildcard})
+			case strings.HasPrefix(sub, "'") && strings.HasSuffix(sub, "'") && len(sub) > 1:
+				segs = append(segs, jpSegment{kind: segName, name: sub[1 : len(sub)-1]})
+			default:
+				i, err := strconv.Atoi(sub)
+				if err != nil {
+					return nil, invalid()
+				}
+
+				segs = append(segs, jpSegment{kind: segIndex, index: i})
+			}
+		default:
+			return nil, invalid()
+		}
+	}
+
+	return segs, nil
+}
+
+func (jp *jsonPath) execute(w io.Writer, data interface{}) error {
+	return executeJSONPath(w, jp.nodes, data)
+}
+
+func executeJSONPath(w io.Writer, nodes []jpNode, data interface{}) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case jpText:
+			if _, err := io.WriteString(w, n.text); err != nil {
+				return err
+			}
+		case jpPath:
+			values := evalJSONPath(n.path, data)
+			texts := make([]string, 0, len(values))
+
+			for _, v := range values {
+				texts = append(texts, jsonPathValue(v))
+			}
+
+			if _, err := io.WriteString(w, strings.Join(texts, " ")); err != nil {
+				return err
+			}
+		case jpRange:
+			for _, v := range evalJSONPath(n.path, data) {
+				if err := executeJSONPath(w, n.body, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func evalJSONPath(segs []jpSegment, data interface{}) []interface{} {
+	values := []interface{}{data}
+
+	for _, s := range segs {
+		var next []interface{}
+
+		for _, v := range values {
+			next = append(next, evalSegment(s, v)...)
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+func evalSegment(s jpSegment, v interface{}) []interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		switch s.kind {
+		case segName:
+			if c, ok := t[s.name]; ok {
+				return []interface{}{c}
+			}
+		case segWildcard:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+
+			sort.Strings(keys)
+
+			out := make([]interface{}, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, t[k])
+			}
+
+			return out
+		}
+	case []interface{}:
+		switch s.kind {
+		case segIndex:
+			i := s.index
+			if i < 0 {
+				i += len(t)
+			}
+
+			if i >= 0 && i < len(t) {
+				return []interface{}{t[i]}
+			}
+		case segWildcard:
+			return t
+		}
+	}
+
+	return nil
+}
+
+func jsonPathValue(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err.Error()
+		}
+
+		return string(b)
+	default:
+		return plainValue(v)
+	}
+}
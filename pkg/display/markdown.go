@@ -0,0 +1,113 @@
+package display
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// MarkdownFormat renders displayables as GitHub flavored
+// markdown tables.
+const MarkdownFormat = "markdown"
+
+func init() {
+	RegisterFormat(MarkdownFormat, SimpleFormat(MarkdownDisplayer))
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+type markdownDisplayer struct {
+	output io.Writer
+}
+
+// Display writes the selected columns of every row as a
+// markdown table.
+//
+// Markdown tables require a header, when headers are disabled
+// an empty one is written instead.
+func (md *markdownDisplayer) Display(d Displayable, f []string) error {
+	w := bufio.NewWriter(md.output)
+	cols := getCols(d, f)
+
+	header := make([]string, len(cols))
+	if !d.NoHeaders() {
+		copy(header, cols)
+	}
+
+	writeMarkdownRow(w, header)
+
+	for i := range header {
+		header[i] = "---"
+	}
+
+	writeMarkdownRow(w, header)
+
+	record := make([]string, len(cols))
+
+	for _, r := range d.KV() {
+		for i, col := range cols {
+			record[i] = plainValue(r[col])
+		}
+
+		writeMarkdownRow(w, record)
+	}
+
+	return w.Flush()
+}
+
+// DisplayMany writes a markdown table for each displayable
+// separated by blank lines.
+func (md *markdownDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	for i, d := range ds {
+		if i > 0 {
+			if _, err := io.WriteString(md.output, "\n"); err != nil {
+				return err
+			}
+		}
+
+		if err := md.Display(d, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (md *markdownDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *md
+	t.output = wrap(md.output)
+
+	return &t
+}
+
+func writeMarkdownRow(w *bufio.Writer, values []string) {
+	_, _ = w.WriteString("|")
+
+	for _, v := range values {
+		_, _ = w.WriteString(" ")
+		_, _ = markdownEscaper.WriteString(w, v)
+		_, _ = w.WriteString(" |")
+	}
+
+	_, _ = w.WriteString("\n")
+}
+
+// MarkdownDisplayer renders displayables as GitHub flavored
+// markdown tables to the provided writer (defaults to os.Stdout).
+//
+// Pipes, backslashes and HTML special characters are escaped
+// and line breaks replaced by <br> so every row stays in a
+// single line.
+func MarkdownDisplayer(output io.Writer) Displayer {
+	return &markdownDisplayer{
+		output: stdout(output),
+	}
+}
@@ -0,0 +1,66 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var snippets = []map[string]interface{}{
+	{"id": 1, "code": "a | b", "html": "<b>&</b>"},
+	{"id": 2, "code": `c:\tmp`, "html": "two\nlines"},
+}
+
+func TestDisplay_MarkdownDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(snippets)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "code", "html"})
+	m.EXPECT().NoHeaders().Return(false)
+
+	want := "| id | code | html |\n" +
+		"| --- | --- | --- |\n" +
+		"| 1 | a \\| b | &lt;b&gt;&amp;&lt;/b&gt; |\n" +
+		"| 2 | c:\\\\tmp | two<br>lines |\n"
+
+	b := bytes.NewBufferString("")
+
+	err := MarkdownDisplayer(b).Display(m, []string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_MarkdownDisplayer_FilteredNoHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().AnyTimes().Return(currencies[:2])
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().NoHeaders().AnyTimes().Return(true)
+	m.EXPECT().Filterable().AnyTimes().Return(true)
+
+	want := "|  |\n" +
+		"| --- |\n" +
+		"| 1 |\n" +
+		"| 1.22 |\n" +
+		"\n" +
+		"|  |\n" +
+		"| --- |\n" +
+		"| 1 |\n" +
+		"| 1.22 |\n"
+
+	b := bytes.NewBufferString("")
+
+	err := MarkdownDisplayer(b).DisplayMany([]Displayable{m, m}, []string{"Quote"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
@@ -0,0 +1,220 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// DefaultPager is the command used to page the output when
+// the PAGER environment variable is not set.
+const DefaultPager = "less -FRX"
+
+// PageRequest describes the page requested to a paginated source.
+//
+// Sources using cursors receive the cursor of the following page
+// on Cursor, sources using page numbers receive it on Page.
+type PageRequest struct {
+	Limit  int
+	Page   int
+	Cursor string
+}
+
+// PageInfo describes the page returned by a paginated source.
+type PageInfo struct {
+	Page       int
+	Total      int
+	NextCursor string
+	HasMore    bool
+}
+
+// Next returns the request for the page following the given one
+// and false when there are no more pages.
+func (pi PageInfo) Next(req PageRequest) (PageRequest, bool) {
+	switch {
+	case pi.NextCursor != "":
+		req.Cursor = pi.NextCursor
+
+		return req, true
+	case pi.HasMore:
+		req.Page++
+
+		return req, true
+	default:
+		return req, false
+	}
+}
+
+// Hint returns a message describing how to retrieve the following
+// page, or an empty string when there are no more pages.
+func (pi PageInfo) Hint() string {
+	switch {
+	case pi.NextCursor != "":
+		return fmt.Sprintf("more results available, use --cursor %s or --all to retrieve them", pi.NextCursor)
+	case pi.HasMore:
+		return fmt.Sprintf("more results available, use --page %d or --all to retrieve them", pi.Page+1)
+	default:
+		return ""
+	}
+}
+
+// Paginated is implemented by displayables that hold a single
+// page of a larger result set.
+type Paginated interface {
+	Displayable
+	PageInfo() PageInfo
+}
+
+// PageFetcher retrieves a page of results from a paginated source.
+type PageFetcher func(req PageRequest) (Displayable, PageInfo, error)
+
+type page struct {
+	Displayable
+	rows []map[string]interface{}
+	info PageInfo
+}
+
+// KV is a displayable group of key value.
+func (p *page) KV() []map[string]interface{} {
+	return p.rows
+}
+
+// PageInfo returns the details of the page.
+func (p *page) PageInfo() PageInfo {
+	return p.info
+}
+
+// FetchPage retrieves the requested page.
+func FetchPage(fetch PageFetcher, req PageRequest) (Paginated, error) {
+	d, info, err := fetch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &page{Displayable: d, rows: d.KV(), info: info}, nil
+}
+
+// FetchAll retrieves every page starting at the requested one and
+// returns a displayable holding the rows of all of them.
+func FetchAll(fetch PageFetcher, req PageRequest) (Paginated, error) {
+	var all *page
+
+	for {
+		p, err := FetchPage(fetch, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if all == nil {
+			all = p.(*page)
+		} else {
+			all.rows = append(all.rows, p.KV()...)
+			all.info = p.PageInfo()
+		}
+
+		next, ok := p.PageInfo().Next(req)
+		if !ok || len(p.KV()) == 0 || next == req {
+			break
+		}
+
+		req = next
+	}
+
+	all.info.HasMore = false
+	all.info.NextCursor = ""
+
+	return all, nil
+}
+
+// Pager buffers the output and, once closed, pipes it through the
+// pager defined by the PAGER environment variable (DefaultPager if
+// unset) when the output is a terminal and the content does not fit
+// in its height. Otherwise the content is written to the output,
+// right away when the output is not a terminal.
+//
+// Setting PAGER to an empty value disables paging.
+type Pager struct {
+	output io.Writer
+	buf    bytes.Buffer
+	height int
+	cmd    string
+}
+
+// NewPager creates a pager for the provided output (defaults to
+// os.Stdout).
+func NewPager(output io.Writer) *Pager {
+	output = stdout(output)
+
+	p := &Pager{output: output, cmd: DefaultPager}
+
+	if v, ok := os.LookupEnv("PAGER"); ok {
+		p.cmd = v
+	}
+
+	if isTerminal(output) {
+		if _, h, err := term.GetSize(int(output.(*os.File).Fd())); err == nil {
+			p.height = h
+		}
+	}
+
+	return p
+}
+
+// Write buffers the content until the pager is closed, or writes
+// it to the output when paging is not possible.
+func (p *Pager) Write(b []byte) (int, error) {
+	if !p.pages() {
+		return p.output.Write(b)
+	}
+
+	return p.buf.Write(b)
+}
+
+// Close writes the buffered content, through the pager command
+// when required.
+func (p *Pager) Close() error {
+	if !p.needed() {
+		_, err := p.buf.WriteTo(p.output)
+
+		return err
+	}
+
+	args := strings.Fields(p.cmd)
+
+	cmd := exec.Command(args[0], args[1:]...) // #nosec G204
+	cmd.Stdin = &p.buf
+	cmd.Stdout = p.output
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	content := p.buf.Bytes()
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		// the pager could not be started, write the content as is.
+		_, err = p.output.Write(content)
+	}
+
+	return err
+}
+
+// pages reports whether the output can be paged.
+func (p *Pager) pages() bool {
+	return p.height > 0 && strings.TrimSpace(p.cmd) != ""
+}
+
+func (p *Pager) needed() bool {
+	return p.pages() && bytes.Count(p.buf.Bytes(), []byte("\n")) >= p.height
+}
@@ -0,0 +1,138 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cursorFetcher(calls *[]PageRequest) PageFetcher {
+	pages := map[string][]map[string]interface{}{
+		"":   currencies[:1],
+		"c2": currencies[1:2],
+		"c3": currencies[2:],
+	}
+	next := map[string]string{"": "c2", "c2": "c3"}
+
+	return func(req PageRequest) (Displayable, PageInfo, error) {
+		*calls = append(*calls, req)
+
+		return &staticDisplayable{rows: pages[req.Cursor], cols: currencyCol},
+			PageInfo{NextCursor: next[req.Cursor], Total: 3},
+			nil
+	}
+}
+
+func TestFetchPage(t *testing.T) {
+	var calls []PageRequest
+
+	p, err := FetchPage(cursorFetcher(&calls), PageRequest{Limit: 1, Cursor: "c2"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, currencies[1:2], p.KV())
+	assert.Equal(t, PageInfo{NextCursor: "c3", Total: 3}, p.PageInfo())
+	assert.Equal(t, "more results available, use --cursor c3 or --all to retrieve them", p.PageInfo().Hint())
+}
+
+func TestFetchAll_Cursor(t *testing.T) {
+	var calls []PageRequest
+
+	p, err := FetchAll(cursorFetcher(&calls), PageRequest{Limit: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, currencies, p.KV())
+	assert.Equal(t, currencyCol, p.Cols())
+	assert.Equal(t, "", p.PageInfo().Hint())
+	assert.Equal(t, []PageRequest{
+		{Limit: 1},
+		{Limit: 1, Cursor: "c2"},
+		{Limit: 1, Cursor: "c3"},
+	}, calls)
+}
+
+func TestFetchAll_PageNumbers(t *testing.T) {
+	fetch := func(req PageRequest) (Displayable, PageInfo, error) {
+		return &staticDisplayable{rows: currencies[req.Page-1 : req.Page], cols: currencyCol},
+			PageInfo{Page: req.Page, HasMore: req.Page < 3},
+			nil
+	}
+
+	p, err := FetchAll(fetch, PageRequest{Page: 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, currencies[1:], p.KV())
+	assert.Equal(t, 3, p.PageInfo().Page)
+}
+
+func TestFetchAll_Error(t *testing.T) {
+	failure := errors.New("unavailable")
+
+	_, err := FetchAll(func(req PageRequest) (Displayable, PageInfo, error) {
+		return nil, PageInfo{}, failure
+	}, PageRequest{})
+
+	assert.Equal(t, failure, err)
+}
+
+func TestPageInfo_Next(t *testing.T) {
+	req := PageRequest{Limit: 10, Page: 1}
+
+	next, ok := PageInfo{HasMore: true}.Next(req)
+	assert.True(t, ok)
+	assert.Equal(t, PageRequest{Limit: 10, Page: 2}, next)
+
+	_, ok = PageInfo{}.Next(req)
+	assert.False(t, ok)
+	assert.Equal(t, "more results available, use --page 3 or --all to retrieve them", PageInfo{Page: 2, HasMore: true}.Hint())
+}
+
+func TestPager_NotATerminal(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	p := NewPager(b)
+	_, _ = p.Write([]byte(strings.Repeat("line\n", 500)))
+
+	// nothing is buffered when paging is not possible.
+	assert.Equal(t, 500, strings.Count(b.String(), "\n"))
+
+	assert.Nil(t, p.Close())
+	assert.Equal(t, 500, strings.Count(b.String(), "\n"))
+}
+
+func TestPager_PipesThroughCommand(t *testing.T) {
+	if _, err := exec.LookPath("tr"); err != nil {
+		t.Skip("tr is not available")
+	}
+
+	b := bytes.NewBufferString("")
+
+	p := &Pager{output: b, height: 2, cmd: "tr a-z A-Z"}
+	_, _ = p.Write([]byte("one\ntwo\nthree\n"))
+
+	assert.Nil(t, p.Close())
+	assert.Equal(t, "ONE\nTWO\nTHREE\n", b.String())
+}
+
+func TestPager_FitsTheTerminal(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	p := &Pager{output: b, height: 10, cmd: "false"}
+	_, _ = p.Write([]byte("one\ntwo\n"))
+
+	assert.Nil(t, p.Close())
+	assert.Equal(t, "one\ntwo\n", b.String())
+}
+
+func TestPager_MissingCommand(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	p := &Pager{output: b, height: 1, cmd: "admiral-missing-pager"}
+	_, _ = p.Write([]byte("one\ntwo\n"))
+
+	assert.Nil(t, p.Close())
+	assert.Equal(t, "one\ntwo\n", b.String())
+}
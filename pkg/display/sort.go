@@ -0,0 +1,226 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnknownColumn is returned when a column that is not part of
+// a displayable is requested.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// SortKey describes a column used to sort rows.
+type SortKey struct {
+	Col  string
+	Desc bool
+}
+
+// ParseSortKeys parses a comma separated list of columns into
+// sort keys.
+//
+// Columns are sorted in ascending order unless they are prefixed
+// with '-' or suffixed with ':desc', e.g. "status,-age".
+func ParseSortKeys(req string) []SortKey {
+	var keys []SortKey
+
+	for _, k := range strings.Split(req, ",") {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+
+		key := SortKey{Col: k}
+
+		switch {
+		case strings.HasPrefix(k, "-"):
+			key = SortKey{Col: k[1:], Desc: true}
+		case strings.HasPrefix(k, "+"):
+			key.Col = k[1:]
+		case strings.HasSuffix(k, ":desc"):
+			key = SortKey{Col: strings.TrimSuffix(k, ":desc"), Desc: true}
+		case strings.HasSuffix(k, ":asc"):
+			key.Col = strings.TrimSuffix(k, ":asc")
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+type sortedDisplayable struct {
+	Displayable
+	keys []SortKey
+}
+
+// Unwrap returns the sorted displayable.
+func (sd *sortedDisplayable) Unwrap() Displayable {
+	return sd.Displayable
+}
+
+// KV returns the rows of the wrapped displayable sorted by
+// the configured keys.
+func (sd *sortedDisplayable) KV() []map[string]interface{} {
+	rows := append([]map[string]interface{}{}, sd.Displayable.KV()...)
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, k := range sd.keys {
+			c := compareValues(rows[i][k.Col], rows[j][k.Col])
+			if c == 0 {
+				continue
+			}
+
+			if k.Desc {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+
+	return rows
+}
+
+// Sorted wraps a displayable so its rows are returned ordered by
+// the given keys, the first key taking precedence.
+//
+// Values are compared according to their type (numbers, booleans,
+// time.Time and strings), nil values are sorted first. An error
+// is returned if a key is not one of the displayable columns.
+func Sorted(d Displayable, keys ...SortKey) (Displayable, error) {
+	if len(keys) == 0 {
+		return d, nil
+	}
+
+	for _, k := range keys {
+		if err := checkColumn(d, k.Col); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sortedDisplayable{
+		Displayable: d,
+		keys:        keys,
+	}, nil
+}
+
+// checkColumn returns an error if col is not part of the columns
+// or the column descriptions of the displayable.
+func checkColumn(d Displayable, col string) error {
+	available := availableCols(d)
+
+	for _, c := range available {
+		if c == col {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"%w %q, possible values are %s",
+		ErrUnknownColumn,
+		col,
+		strings.Join(available, ","),
+	)
+}
+
+// availableCols returns the displayable columns followed by
+// any other column described on its ColMap.
+func availableCols(d Displayable) []string {
+	cols := append([]string{}, d.Cols()...)
+
+	seen := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		seen[c] = true
+	}
+
+	var extra []string
+
+	for c := range d.ColMap() {
+		if !seen[c] {
+			extra = append(extra, c)
+		}
+	}
+
+	sort.Strings(extra)
+
+	return append(cols, extra...)
+}
+
+// compareValues returns -1, 0 or 1 depending on a being lower,
+// equal or greater than b.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			switch {
+			case ta.Before(tb):
+				return -1
+			case ta.After(tb):
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	if fa, ok := numeric(a); ok {
+		if fb, ok := numeric(b); ok {
+			return compareFloats(fa, fb)
+		}
+	}
+
+	if ba, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			switch {
+			case ba == bb:
+				return 0
+			case bb:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func numeric(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
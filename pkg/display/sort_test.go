@@ -0,0 +1,134 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	cases := []struct {
+		name  string
+		given string
+		want  []SortKey
+	}{
+		{"empty", "", nil},
+		{"single ascending", "name", []SortKey{{Col: "name"}}},
+		{
+			"prefixes",
+			"-age, +name",
+			[]SortKey{{Col: "age", Desc: true}, {Col: "name"}},
+		},
+		{
+			"suffixes",
+			"age:desc,name:asc",
+			[]SortKey{{Col: "age", Desc: true}, {Col: "name"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, ParseSortKeys(c.given))
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().ColMap().AnyTimes().Return(currencyColMap)
+	m.EXPECT().NoHeaders().Return(false)
+
+	d, err := Sorted(m, SortKey{Col: "Quote", Desc: true})
+	assert.Nil(t, err)
+
+	want := `Symbol    Quote
+MXN       24.45
+USD       1.22
+EUR       1
+`
+
+	b := bytes.NewBufferString("")
+	_ = DefaultDisplayer(b).Display(d, nil)
+
+	assert.Equal(t, want, b.String())
+	assert.Equal(t, "EUR", currencies[0]["Symbol"])
+}
+
+func TestSorted_MultipleKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rows := []map[string]interface{}{
+		{"name": "c", "active": true, "age": 30},
+		{"name": "a", "active": false, "age": 30},
+		{"name": "b", "active": true, "age": 25},
+		{"name": "d", "active": nil, "age": 25},
+	}
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(rows)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"name", "active", "age"})
+	m.EXPECT().ColMap().AnyTimes().Return(map[string]string{})
+
+	d, err := Sorted(m, ParseSortKeys("-age,active")...)
+	assert.Nil(t, err)
+
+	var got []interface{}
+	for _, r := range d.KV() {
+		got = append(got, r["name"])
+	}
+
+	assert.Equal(t, []interface{}{"a", "c", "d", "b"}, got)
+}
+
+func TestSorted_UnknownColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().ColMap().AnyTimes().Return(map[string]string{"Name": "Currency name"})
+
+	_, err := Sorted(m, SortKey{Col: "Rate"})
+
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+	assert.Contains(t, err.Error(), "Symbol,Quote,Name")
+}
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name string
+		a, b interface{}
+		want int
+	}{
+		{"nil first", nil, 1, -1},
+		{"both nil", nil, nil, 0},
+		{"int and float", 2, 1.5, 1},
+		{"mixed integer types", int64(3), uint8(3), 0},
+		{"numbers are not compared as text", 9, 10, -1},
+		{"bool", false, true, -1},
+		{"time", now, now.Add(time.Second), -1},
+		{"duration", time.Minute, time.Second, 1},
+		{"string", "b", "a", 1},
+		{"mixed types fall back to text", "10", 9, -1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, compareValues(c.a, c.b))
+		})
+	}
+}
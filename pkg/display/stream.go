@@ -0,0 +1,368 @@
+package display
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultStreamSample is the number of rows used to size the
+// columns of a stream when none is configured.
+const DefaultStreamSample = 100
+
+// DefaultStreamSampleTimeout is the longest a stream waits for
+// its sample before writing the rows received so far, when none
+// is configured.
+const DefaultStreamSampleTimeout = 500 * time.Millisecond
+
+// RowIterator produces the rows of a stream one at a time.
+//
+// Next advances to the following row returning false once the
+// rows are exhausted or an error happened, which is then
+// reported by Err.
+type RowIterator interface {
+	Next() bool
+	Row() map[string]interface{}
+	Err() error
+}
+
+// ContextRowIterator is implemented by iterators able to stop
+// waiting for the following row once the context is done, e.g.
+// those created with ChannelRows.
+type ContextRowIterator interface {
+	RowIterator
+	NextContext(ctx context.Context) bool
+}
+
+// Streamable describes an output handler whose rows are
+// produced incrementally instead of being held in memory.
+type Streamable interface {
+	Rows() RowIterator
+	Cols() []string
+	ColMap() map[string]string
+	NoHeaders() bool
+	Filterable() bool
+}
+
+// Streamer renders streamables as their rows are produced.
+type Streamer interface {
+	Stream(Streamable, []string) error
+}
+
+// StreamingDisplayer renders both streamables and displayables.
+type StreamingDisplayer interface {
+	Streamer
+	Displayer
+}
+
+type sliceRows struct {
+	rows []map[string]interface{}
+	pos  int
+}
+
+func (sr *sliceRows) Next() bool {
+	if sr.pos >= len(sr.rows) {
+		return false
+	}
+
+	sr.pos++
+
+	return true
+}
+
+func (sr *sliceRows) Row() map[string]interface{} {
+	return sr.rows[sr.pos-1]
+}
+
+func (sr *sliceRows) Err() error {
+	return nil
+}
+
+// SliceRows iterates over rows that are already in memory.
+func SliceRows(rows []map[string]interface{}) RowIterator {
+	return &sliceRows{rows: rows}
+}
+
+type chanRows struct {
+	ch  <-chan map[string]interface{}
+	row map[string]interface{}
+}
+
+func (cr *chanRows) Next() bool {
+	return cr.NextContext(context.Background())
+}
+
+// NextContext waits for the following row until the context is
+// done.
+func (cr *chanRows) NextContext(ctx context.Context) bool {
+	select {
+	case row, ok := <-cr.ch:
+		cr.row = row
+
+		return ok
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (cr *chanRows) Row() map[string]interface{} {
+	return cr.row
+}
+
+func (cr *chanRows) Err() error {
+	return nil
+}
+
+// ChannelRows iterates over the rows received from a channel
+// until it is closed.
+func ChannelRows(ch <-chan map[string]interface{}) RowIterator {
+	return &chanRows{ch: ch}
+}
+
+type funcRows struct {
+	next func() (map[string]interface{}, error)
+	row  map[string]interface{}
+	err  error
+}
+
+func (fr *funcRows) Next() bool {
+	if fr.err != nil {
+		return false
+	}
+
+	fr.row, fr.err = fr.next()
+
+	return fr.err == nil
+}
+
+func (fr *funcRows) Row() map[string]interface{} {
+	return fr.row
+}
+
+func (fr *funcRows) Err() error {
+	if errors.Is(fr.err, io.EOF) {
+		return nil
+	}
+
+	return fr.err
+}
+
+// FuncRows iterates over the rows returned by next until it
+// returns an error, io.EOF signals the end of the rows.
+func FuncRows(next func() (map[string]interface{}, error)) RowIterator {
+	return &funcRows{next: next}
+}
+
+type displayableStream struct {
+	Displayable
+}
+
+// Rows iterates over the rows of the displayable.
+func (ds *displayableStream) Rows() RowIterator {
+	return SliceRows(ds.KV())
+}
+
+// StreamOf adapts a displayable into a streamable.
+func StreamOf(d Displayable) Streamable {
+	return &displayableStream{Displayable: d}
+}
+
+// StreamConfig defines the behavior of the stream displayer.
+type StreamConfig struct {
+	// Sample is the number of rows buffered to size the
+	// columns, defaults to DefaultStreamSample.
+	Sample int
+	// SampleTimeout is the longest the sample is waited for
+	// before writing the rows received so far, defaults to
+	// DefaultStreamSampleTimeout. It only applies to iterators
+	// implementing ContextRowIterator.
+	SampleTimeout time.Duration
+	// Exact displays exact values instead of humanized ones.
+	Exact bool
+}
+
+type streamDisplayer struct {
+	output  io.Writer
+	sample  int
+	timeout time.Duration
+	exact   bool
+}
+
+// Stream writes the rows of the streamable as they are produced,
+// sizing the columns from the first sampled rows. Later values
+// wider than their column shift the rest of their line.
+func (sd *streamDisplayer) Stream(s Streamable, f []string) error {
+	return sd.StreamContext(context.Background(), s, f)
+}
+
+// StreamContext streams the rows of the streamable until they
+// are exhausted or the context is done. Rows received before the
+// sample timeout are written even if the sample is not complete.
+// Only iterators implementing ContextRowIterator stop waiting for
+// a row when the context is done, others are checked between rows.
+func (sd *streamDisplayer) StreamContext(ctx context.Context, s Streamable, f []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	cols := getCols(s, f)
+	if len(cols) == 0 {
+		return nil
+	}
+
+	var sampled [][]string
+
+	if !s.NoHeaders() {
+		sampled = append(sampled, cols)
+	}
+
+	it := s.Rows()
+
+	next := func(ctx context.Context) bool {
+		if ci, ok := it.(ContextRowIterator); ok {
+			return ci.NextContext(ctx)
+		}
+
+		return ctx.Err() == nil && it.Next()
+	}
+
+	// slow sources, e.g. tailing events, get their sample written
+	// once the timeout expires.
+	sampleCtx, cancel := context.WithTimeout(ctx, sd.timeout)
+	defer cancel()
+
+	more := true
+
+	for n := 0; n < sd.sample; n++ {
+		if !next(sampleCtx) {
+			more = sampleCtx.Err() != nil && ctx.Err() == nil
+
+			break
+		}
+
+		sampled = append(sampled, rowCells(it.Row(), cols, humanized(sd.exact)))
+	}
+
+	widths := make([]int, len(cols))
+
+	for _, row := range sampled {
+		for i, c := range row {
+			if w := StringWidth(c); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	for _, row := range sampled {
+		if err := sd.writeRow(row, widths); err != nil {
+			return err
+		}
+	}
+
+	for more && next(ctx) {
+		if err := sd.writeRow(rowCells(it.Row(), cols, humanized(sd.exact)), widths); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return it.Err()
+}
+
+// Display streams the rows of the displayable.
+func (sd *streamDisplayer) Display(d Displayable, f []string) error {
+	return sd.Stream(StreamOf(d), f)
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (sd *streamDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(sd, ds, f)
+}
+
+// DisplayContext streams the rows of the displayable until the
+// context is done.
+func (sd *streamDisplayer) DisplayContext(ctx context.Context, d Displayable, f []string) error {
+	return sd.StreamContext(ctx, StreamOf(d), f)
+}
+
+// DisplayManyContext streams the rows of every displayable until
+// the context is done.
+func (sd *streamDisplayer) DisplayManyContext(ctx context.Context, ds []Displayable, f []string) error {
+	for _, d := range ds {
+		if err := sd.DisplayContext(ctx, d, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sd *streamDisplayer) exactCopy() Displayer {
+	t := *sd
+	t.exact = true
+
+	return &t
+}
+
+func (sd *streamDisplayer) writeRow(row []string, widths []int) error {
+	var b strings.Builder
+
+	for i, c := range row {
+		b.WriteString(c)
+
+		if i == len(row)-1 {
+			continue
+		}
+
+		pad := widths[i] - StringWidth(c)
+		if pad < 0 {
+			pad = 0
+		}
+
+		b.WriteString(padding(pad + tablePadding))
+	}
+
+	b.WriteString("\n")
+
+	_, err := io.WriteString(sd.output, b.String())
+
+	return err
+}
+
+func rowCells(r map[string]interface{}, cols []string, human bool) []string {
+	cells := make([]string, len(cols))
+
+	for i, col := range cols {
+		cells[i] = formatValue(col, r[col], human)
+	}
+
+	return cells
+}
+
+// StreamDisplayer constructs a column based output to the provided
+// writer (defaults to os.Stdout) that writes rows as soon as they
+// are produced instead of buffering the whole table.
+//
+// Displayables are streamed from the rows returned by KV.
+func StreamDisplayer(output io.Writer, config StreamConfig) StreamingDisplayer {
+	if config.Sample <= 0 {
+		config.Sample = DefaultStreamSample
+	}
+
+	if config.SampleTimeout <= 0 {
+		config.SampleTimeout = DefaultStreamSampleTimeout
+	}
+
+	return &streamDisplayer{
+		output:  stdout(output),
+		sample:  config.Sample,
+		timeout: config.SampleTimeout,
+		exact:   config.Exact,
+	}
+}
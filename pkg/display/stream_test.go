@@ -0,0 +1,180 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventStream struct {
+	rows RowIterator
+}
+
+func (es *eventStream) Rows() RowIterator         { return es.rows }
+func (es *eventStream) Cols() []string            { return []string{"id", "event"} }
+func (es *eventStream) ColMap() map[string]string { return map[string]string{} }
+func (es *eventStream) NoHeaders() bool           { return false }
+func (es *eventStream) Filterable() bool          { return true }
+
+// lineWriter forwards every write to a channel.
+type lineWriter struct {
+	lines chan string
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	lw.lines <- string(p)
+
+	return len(p), nil
+}
+
+func TestStreamDisplayer_Stream(t *testing.T) {
+	rows := make(chan map[string]interface{})
+	out := &lineWriter{lines: make(chan string)}
+	done := make(chan error)
+
+	go func() {
+		done <- StreamDisplayer(out, StreamConfig{Sample: 1}).Stream(&eventStream{ChannelRows(rows)}, []string{"event", "id"})
+	}()
+
+	var got []string
+
+	read := func() {
+		select {
+		case l := <-out.lines:
+			got = append(got, l)
+		case <-time.After(time.Second):
+			t.Fatal("rows were not written before the stream ended")
+		}
+	}
+
+	rows <- map[string]interface{}{"id": 1, "event": "created"}
+	read()
+	read()
+
+	rows <- map[string]interface{}{"id": 2, "event": "started"}
+	read()
+
+	rows <- map[string]interface{}{"id": 3, "event": "a much longer event"}
+	read()
+
+	close(rows)
+
+	assert.Nil(t, <-done)
+	assert.Equal(t, []string{
+		"event      id\n",
+		"created    1\n",
+		"started    2\n",
+		"a much longer event    3\n",
+	}, got)
+}
+
+func TestStreamDisplayer_IdleSource(t *testing.T) {
+	rows := make(chan map[string]interface{})
+	out := &lineWriter{lines: make(chan string, 3)}
+	done := make(chan error)
+
+	go func() {
+		done <- StreamDisplayer(out, StreamConfig{SampleTimeout: 10 * time.Millisecond}).Stream(&eventStream{ChannelRows(rows)}, nil)
+	}()
+
+	rows <- map[string]interface{}{"id": 1, "event": "created"}
+	rows <- map[string]interface{}{"id": 2, "event": "started"}
+
+	var got []string
+
+	for i := 0; i < 3; i++ {
+		select {
+		case l := <-out.lines:
+			got = append(got, l)
+		case <-time.After(time.Second):
+			t.Fatal("the sample was not written while the source was idle")
+		}
+	}
+
+	rows <- map[string]interface{}{"id": 3, "event": "stopped"}
+	got = append(got, <-out.lines)
+
+	close(rows)
+
+	assert.Nil(t, <-done)
+	assert.Equal(t, []string{
+		"id    event\n",
+		"1     created\n",
+		"2     started\n",
+		"3     stopped\n",
+	}, got)
+}
+
+func TestStreamDisplayer_CancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		rows := ChannelRows(make(chan map[string]interface{}))
+		done <- StreamContext(ctx, StreamDisplayer(ioutil.Discard, StreamConfig{}), &eventStream{rows}, nil)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the stream kept waiting for rows once cancelled")
+	}
+}
+
+func TestStreamDisplayer_FuncRowsError(t *testing.T) {
+	failure := errors.New("connection lost")
+	n := 0
+
+	rows := FuncRows(func() (map[string]interface{}, error) {
+		n++
+		if n > 2 {
+			return nil, failure
+		}
+
+		return map[string]interface{}{"id": n, "event": "tick"}, nil
+	})
+
+	b := bytes.NewBufferString("")
+
+	err := StreamDisplayer(b, StreamConfig{}).Stream(&eventStream{rows}, nil)
+
+	assert.Equal(t, failure, err)
+	assert.Equal(t, "id    event\n1     tick\n2     tick\n", b.String())
+}
+
+func TestStreamDisplayer_FuncRowsEOF(t *testing.T) {
+	rows := FuncRows(func() (map[string]interface{}, error) {
+		return nil, io.EOF
+	})
+
+	assert.False(t, rows.Next())
+	assert.Nil(t, rows.Err())
+}
+
+func TestStreamDisplayer_Display(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+	m.EXPECT().NoHeaders().Return(false)
+
+	b := bytes.NewBufferString("")
+
+	err := StreamDisplayer(b, StreamConfig{}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Symbol    Quote\nEUR       1\nUSD       1.22\nMXN       24.45\n", b.String())
+}
@@ -0,0 +1,256 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TagName contains the golang tag identifier used by FromStructs.
+const TagName = "display"
+
+var errNotStructs = errors.New("a struct or a slice of structs is required")
+
+// StructOptions configures the displayable built by FromStructs.
+type StructOptions struct {
+	NoHeaders        bool
+	DisableFiltering bool
+}
+
+type structField struct {
+	col   string
+	desc  string
+	def   bool
+	index []int
+}
+
+type structDisplayable struct {
+	rows       []map[string]interface{}
+	cols       []string
+	colMap     map[string]string
+	noHeaders  bool
+	filterable bool
+}
+
+// KV is a displayable group of key value.
+func (sd *structDisplayable) KV() []map[string]interface{} {
+	return sd.rows
+}
+
+// Cols returns an array of columns available for displaying.
+func (sd *structDisplayable) Cols() []string {
+	return sd.cols
+}
+
+// ColMap returns a list of columns and its description.
+func (sd *structDisplayable) ColMap() map[string]string {
+	return sd.colMap
+}
+
+// NoHeaders returns a boolean indicating if headers should be displayed
+// or not to the provided output.
+func (sd *structDisplayable) NoHeaders() bool {
+	return sd.noHeaders
+}
+
+// Filterable defines if the displayable can be filtered (columns -> fields).
+func (sd *structDisplayable) Filterable() bool {
+	return sd.filterable
+}
+
+// FromStructs builds a displayable from a struct or a slice of
+// structs (or pointers to them) using reflection.
+//
+// Every exported field becomes a column named after the field,
+// the display tag allows renaming it and describing it for ColMap,
+// marking it as part of the default set of columns or skipping it
+// with '-':
+//
+//	Name string `display:"name,desc=The user name,default"`
+//
+// Nested structs are flattened using dotted column names, e.g.
+// owner.name. When no field is marked as default all of the
+// columns are displayed by default.
+func FromStructs(v interface{}, opts StructOptions) (Displayable, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("%w, got nil", errNotStructs)
+	}
+
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	items := []reflect.Value{rv}
+	et := rv.Type()
+
+	if rv.Kind() == reflect.Ptr {
+		// a nil pointer describes the columns but holds no rows.
+		items = nil
+	}
+
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		et = rv.Type().Elem()
+		items = make([]reflect.Value, rv.Len())
+
+		for i := range items {
+			items[i] = rv.Index(i)
+		}
+	}
+
+	for et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	if et.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %s", errNotStructs, rv.Type())
+	}
+
+	fields := structFields(et, "", false, nil, map[reflect.Type]bool{})
+
+	sd := &structDisplayable{
+		rows:       make([]map[string]interface{}, 0, len(items)),
+		colMap:     make(map[string]string, len(fields)),
+		noHeaders:  opts.NoHeaders,
+		filterable: !opts.DisableFiltering,
+	}
+
+	var all []string
+
+	for _, f := range fields {
+		all = append(all, f.col)
+		sd.colMap[f.col] = f.desc
+
+		if f.def {
+			sd.cols = append(sd.cols, f.col)
+		}
+	}
+
+	if len(sd.cols) == 0 {
+		sd.cols = all
+	}
+
+	for _, item := range items {
+		row := make(map[string]interface{}, len(fields))
+
+		for _, f := range fields {
+			row[f.col] = fieldValue(item, f.index)
+		}
+
+		sd.rows = append(sd.rows, row)
+	}
+
+	return sd, nil
+}
+
+func structFields(
+	t reflect.Type,
+	prefix string,
+	def bool,
+	index []int,
+	visiting map[reflect.Type]bool,
+) []structField {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var fields []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, desc, isDefault := parseDisplayTag(tag)
+		if name == "" {
+			name = f.Name
+		}
+
+		idx := append(append([]int{}, index...), i)
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+			if visiting[ft] {
+				continue
+			}
+
+			nested := prefix + name + "."
+			if f.Anonymous && tag == "" {
+				nested = prefix
+			}
+
+			fields = append(fields, structFields(ft, nested, def || isDefault, idx, visiting)...)
+
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		fields = append(fields, structField{
+			col:   prefix + name,
+			desc:  desc,
+			def:   def || isDefault,
+			index: idx,
+		})
+	}
+
+	return fields
+}
+
+// parseDisplayTag splits a tag such as "name,desc=A, B,default"
+// into its parts; descriptions may contain commas.
+func parseDisplayTag(tag string) (name, desc string, def bool) {
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+
+	inDesc := false
+
+	for _, p := range parts[1:] {
+		switch {
+		case p == "default":
+			def = true
+			inDesc = false
+		case strings.HasPrefix(p, "desc="):
+			desc = strings.TrimPrefix(p, "desc=")
+			inDesc = true
+		case inDesc:
+			desc += "," + p
+		}
+	}
+
+	return name, desc, def
+}
+
+func fieldValue(v reflect.Value, index []int) interface{} {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	return v.Interface()
+}
@@ -0,0 +1,125 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type owner struct {
+	Name  string `display:"name,desc=Owner name"`
+	Email string `display:"-"`
+}
+
+type audit struct {
+	Created time.Time `display:"created,desc=Creation date, in UTC"`
+}
+
+type repository struct {
+	audit
+	ID     int    `display:"id,desc=Repository identifier,default"`
+	Name   string `display:"name,default,desc=Repository name"`
+	Stars  float64
+	Owner  *owner `display:"owner,default"`
+	secret string
+}
+
+func TestFromStructs(t *testing.T) {
+	at := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repos := []repository{
+		{audit{at}, 1, "admiral", 10, &owner{"avocatl", "x@y.z"}, "s"},
+		{audit{at}, 2, "orphan", 1.5, nil, "s"},
+	}
+
+	d, err := FromStructs(repos, StructOptions{})
+
+	assert.Nil(t, err)
+	assert.True(t, d.Filterable())
+	assert.False(t, d.NoHeaders())
+	assert.Equal(t, []string{"id", "name", "owner.name"}, d.Cols())
+	assert.Equal(t, map[string]string{
+		"created":    "Creation date, in UTC",
+		"id":         "Repository identifier",
+		"name":       "Repository name",
+		"Stars":      "",
+		"owner.name": "Owner name",
+	}, d.ColMap())
+	assert.Equal(t, []map[string]interface{}{
+		{"created": at, "id": 1, "name": "admiral", "Stars": float64(10), "owner.name": "avocatl"},
+		{"created": at, "id": 2, "name": "orphan", "Stars": 1.5, "owner.name": nil},
+	}, d.KV())
+}
+
+func TestFromStructs_AllColumnsByDefault(t *testing.T) {
+	d, err := FromStructs(&owner{Name: "avocatl"}, StructOptions{
+		NoHeaders:        true,
+		DisableFiltering: true,
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, d.Filterable())
+	assert.True(t, d.NoHeaders())
+	assert.Equal(t, []string{"name"}, d.Cols())
+	assert.Equal(t, []map[string]interface{}{{"name": "avocatl"}}, d.KV())
+}
+
+func TestFromStructs_Display(t *testing.T) {
+	d, err := FromStructs([]*owner{{Name: "avocatl"}, {Name: "admiral"}}, StructOptions{})
+	assert.Nil(t, err)
+
+	b := bytes.NewBufferString("")
+	_ = DefaultDisplayer(b).Display(d, nil)
+
+	assert.Equal(t, "name\navocatl\nadmiral\n", b.String())
+}
+
+func TestFromStructs_InvalidInput(t *testing.T) {
+	cases := []interface{}{
+		[]string{"a"},
+		10,
+		map[string]string{},
+		nil,
+	}
+
+	for _, c := range cases {
+		_, err := FromStructs(c, StructOptions{})
+
+		assert.ErrorIs(t, err, errNotStructs)
+	}
+
+	var r *repository
+
+	d, err := FromStructs(r, StructOptions{})
+
+	assert.Nil(t, err)
+	assert.Empty(t, d.KV())
+	assert.NotEmpty(t, d.Cols())
+}
+
+func TestParseDisplayTag(t *testing.T) {
+	cases := []struct {
+		tag  string
+		name string
+		desc string
+		def  bool
+	}{
+		{"", "", "", false},
+		{"id", "id", "", false},
+		{"id,default", "id", "", true},
+		{"id,desc=The id", "id", "The id", false},
+		{"id,desc=One, two,default", "id", "One, two", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.tag, func(t *testing.T) {
+			name, desc, def := parseDisplayTag(c.tag)
+
+			assert.Equal(t, c.name, name)
+			assert.Equal(t, c.desc, desc)
+			assert.Equal(t, c.def, def)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ColorMode defines when the output is colorized.
+type ColorMode int
+
+// Supported color modes.
+const (
+	ColorAuto ColorMode = iota
+	ColorAlways
+	ColorNever
+)
+
+var errInvalidColorMode = errors.New("invalid color mode")
+
+// ParseColorMode parses the value of the color flag,
+// accepting auto, always and never.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch strings.ToLower(s) {
+	case "", "auto":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	case "never":
+		return ColorNever, nil
+	default:
+		return ColorAuto, fmt.Errorf("%w %q, possible values are auto,always,never", errInvalidColorMode, s)
+	}
+}
+
+// Enabled reports whether colors should be used when writing
+// to the given output.
+//
+// On auto mode colors are only used on terminals and when the
+// NO_COLOR environment variable is not set.
+func (cm ColorMode) Enabled(output io.Writer) bool {
+	switch cm {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminal(output)
+}
+
+func isTerminal(output io.Writer) bool {
+	f, ok := output.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Color is an ANSI terminal foreground color.
+type Color int
+
+// Supported colors.
+const (
+	DefaultColor Color = 0
+	Red          Color = 31
+	Green        Color = 32
+	Yellow       Color = 33
+	Blue         Color = 34
+	Magenta      Color = 35
+	Cyan         Color = 36
+	Gray         Color = 90
+)
+
+// Style describes how a value is rendered.
+//
+// Text replaces the formatted value when it is not empty.
+type Style struct {
+	Text      string
+	Color     Color
+	Bold      bool
+	Faint     bool
+	Underline bool
+	Reverse   bool
+}
+
+// Render wraps the text with the escape sequences of the style.
+func (s Style) Render(text string) string {
+	var codes []string
+
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+
+	if s.Faint {
+		codes = append(codes, "2")
+	}
+
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+
+	if s.Reverse {
+		codes = append(codes, "7")
+	}
+
+	if s.Color != DefaultColor {
+		codes = append(codes, fmt.Sprint(int(s.Color)))
+	}
+
+	if len(codes) == 0 || text == "" {
+		return text
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m" + text + "\x1b[0m"
+}
+
+// StyleFunc returns the style of a value on the given column,
+// the zero Style leaves the value untouched.
+type StyleFunc func(col string, value interface{}) Style
+
+// Styles combines multiple style hooks, the first one
+// returning a non zero style wins.
+func Styles(fns ...StyleFunc) StyleFunc {
+	return func(col string, value interface{}) Style {
+		for _, fn := range fns {
+			if s := fn(col, value); s != (Style{}) {
+				return s
+			}
+		}
+
+		return Style{}
+	}
+}
+
+// ValueColors colors the values of a column, e.g. a status
+// column with {"failed": Red, "running": Green}.
+func ValueColors(column string, colors map[string]Color) StyleFunc {
+	return func(col string, value interface{}) Style {
+		if col != column {
+			return Style{}
+		}
+
+		return Style{Color: colors[plainValue(value)]}
+	}
+}
+
+// BoolMarks renders boolean values as a green ✓ or a red ✗.
+func BoolMarks(col string, value interface{}) Style {
+	b, ok := value.(bool)
+	if !ok {
+		return Style{}
+	}
+
+	if b {
+		return Style{Text: "✓", Color: Green}
+	}
+
+	return Style{Text: "✗", Color: Red}
+}
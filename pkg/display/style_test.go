@@ -0,0 +1,120 @@
+package display
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobs = []map[string]interface{}{
+	{"name": "build", "status": "failed", "cached": true},
+	{"name": "test", "status": "running", "cached": false},
+}
+
+var jobStyles = Styles(
+	ValueColors("status", map[string]Color{"failed": Red}),
+	BoolMarks,
+)
+
+func TestParseColorMode(t *testing.T) {
+	cases := map[string]ColorMode{
+		"":       ColorAuto,
+		"auto":   ColorAuto,
+		"ALWAYS": ColorAlways,
+		"never":  ColorNever,
+	}
+
+	for given, want := range cases {
+		got, err := ParseColorMode(given)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseColorMode("sometimes")
+	assert.ErrorIs(t, err, errInvalidColorMode)
+}
+
+func TestColorMode_Enabled(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	assert.True(t, ColorAlways.Enabled(b))
+	assert.False(t, ColorNever.Enabled(b))
+	assert.False(t, ColorAuto.Enabled(b))
+
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+
+	assert.False(t, ColorAuto.Enabled(os.Stdout))
+}
+
+func TestStyle_Render(t *testing.T) {
+	assert.Equal(t, "plain", Style{}.Render("plain"))
+	assert.Equal(t, "\x1b[31mred\x1b[0m", Style{Color: Red}.Render("red"))
+	assert.Equal(t, "\x1b[1;4;32mok\x1b[0m", Style{Color: Green, Bold: true, Underline: true}.Render("ok"))
+	assert.Equal(t, "", Style{Color: Red}.Render(""))
+}
+
+func TestDisplay_TableDisplayer_Styled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(jobs)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"status", "cached", "name"})
+	m.EXPECT().NoHeaders().Return(false)
+
+	want := "status     cached    name\n" +
+		"\x1b[31mfailed\x1b[0m     \x1b[32m✓\x1b[0m         build\n" +
+		"running    \x1b[31m✗\x1b[0m         test\n"
+
+	b := bytes.NewBufferString("")
+
+	err := TableDisplayer(b, TableConfig{Style: jobStyles, Color: ColorAlways}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+	assert.Equal(t, "status     cached    name\nfailed     ✓         build\nrunning    ✗         test\n", StripANSI(b.String()))
+}
+
+func TestDisplay_TableDisplayer_StyleDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(jobs)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"status", "cached"})
+	m.EXPECT().NoHeaders().Return(true)
+
+	b := bytes.NewBufferString("")
+
+	err := TableDisplayer(b, TableConfig{Style: jobStyles}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "failed     true\nrunning    false\n", b.String())
+}
+
+func TestDisplay_TableDisplayer_EscapedValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return([]map[string]interface{}{
+		{"a": "\x1b[1mbold\x1b[0m", "b": "x"},
+		{"a": "plain", "b": "y"},
+	})
+	m.EXPECT().Cols().AnyTimes().Return([]string{"a", "b"})
+	m.EXPECT().NoHeaders().Return(true)
+
+	b := bytes.NewBufferString("")
+
+	_ = DefaultDisplayer(b).Display(m, nil)
+
+	assert.Equal(t, "bold     x\nplain    y\n", StripANSI(b.String()))
+}
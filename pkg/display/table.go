@@ -0,0 +1,218 @@
+package display
+
+import (
+	"io"
+	"strings"
+)
+
+// Overflow defines how values wider than their column
+// are rendered.
+type Overflow int
+
+// Supported overflow policies.
+const (
+	OverflowTruncate Overflow = iota
+	OverflowWrap
+)
+
+// tablePadding is the number of spaces between columns.
+const tablePadding = 4
+
+// minColWidth is the narrowest a column is shrunk to fit
+// the table width.
+const minColWidth = 5
+
+// TableConfig defines the appearance of the column
+// based output.
+type TableConfig struct {
+	// Width is the maximum line width, 0 disables truncation.
+	Width int
+	// Wide disables truncation and wrapping regardless of Width.
+	Wide bool
+	// Overflow sets the policy of specific columns, columns
+	// not listed are truncated.
+	Overflow map[string]Overflow
+	// Style is called for every value to customize how it
+	// is rendered when styles are enabled.
+	Style StyleFunc
+	// Color defines when styles are enabled.
+	Color ColorMode
+	// Exact displays exact values instead of humanized ones,
+	// e.g. timestamps instead of relative times.
+	Exact bool
+}
+
+// TableDisplayer constructs a column based output to the
+// provided writer (defaults to os.Stdout) using the given
+// configuration.
+//
+// Column widths are measured in terminal cells, so East Asian
+// wide characters and ANSI escape sequences are aligned
+// correctly. When the table is wider than the configured width
+// the widest columns are shrunk and their values truncated with
+// an ellipsis or wrapped, following each column policy.
+//
+// Values are styled by the configured style hook only when
+// enabled by the color mode, so piped output stays plain.
+func TableDisplayer(output io.Writer, config TableConfig) Displayer {
+	output = stdout(output)
+
+	return &stdDisplayer{
+		output:  output,
+		config:  config,
+		colored: config.Color.Enabled(output),
+	}
+}
+
+func (sd *stdDisplayer) render(cols []string, cells [][]string, styles [][]Style) error {
+	config := sd.config
+	if len(cols) == 0 {
+		return nil
+	}
+
+	natural := make([]int, len(cols))
+
+	for _, row := range cells {
+		for i, c := range row {
+			for _, line := range strings.Split(c, "\n") {
+				if n := StringWidth(line); n > natural[i] {
+					natural[i] = n
+				}
+			}
+		}
+	}
+
+	widths := natural
+	limited := config.Width > 0 && !config.Wide
+
+	if limited {
+		widths = fitWidths(natural, config.Width)
+	}
+
+	var b strings.Builder
+
+	for r, row := range cells {
+		lines := make([][]string, len(row))
+		height := 1
+
+		for i, c := range row {
+			// every line of multi-line values is kept, e.g. pretty
+			// printed json, and fitted on its own.
+			switch {
+			case !limited:
+				lines[i] = strings.Split(c, "\n")
+			case config.Overflow[cols[i]] == OverflowWrap:
+				lines[i] = Wrap(c, widths[i])
+			default:
+				lines[i] = strings.Split(c, "\n")
+				for l, line := range lines[i] {
+					lines[i][l] = Truncate(line, widths[i])
+				}
+			}
+
+			if len(lines[i]) > height {
+				height = len(lines[i])
+			}
+		}
+
+		for l := 0; l < height; l++ {
+			var line strings.Builder
+
+			for i := range row {
+				var text string
+				if l < len(lines[i]) {
+					text = lines[i][l]
+				}
+
+				pad := widths[i] - StringWidth(text) + tablePadding
+
+				line.WriteString(styles[r][i].Render(text))
+
+				if i < len(row)-1 {
+					line.WriteString(padding(pad))
+				}
+			}
+
+			// continuation lines of wrapped values are not padded.
+			if l > 0 {
+				b.WriteString(strings.TrimRight(line.String(), " "))
+			} else {
+				b.WriteString(line.String())
+			}
+
+			b.WriteString("\n")
+		}
+
+		if _, err := io.WriteString(sd.output, b.String()); err != nil {
+			return err
+		}
+
+		b.Reset()
+	}
+
+	return nil
+}
+
+// fitWidths shrinks the widest columns until the table fits
+// in the given width, narrow columns keep their natural width.
+func fitWidths(natural []int, width int) []int {
+	widths := append([]int{}, natural...)
+	avail := width - tablePadding*(len(widths)-1)
+
+	fixed := make([]bool, len(widths))
+	pending := len(widths)
+
+	for pending > 0 {
+		share := avail / pending
+		changed := false
+
+		for i, n := range natural {
+			if !fixed[i] && n <= share {
+				fixed[i] = true
+				avail -= n
+				pending--
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	if pending == 0 {
+		return widths
+	}
+
+	share, extra := avail/pending, avail%pending
+
+	for i := range widths {
+		if fixed[i] {
+			continue
+		}
+
+		widths[i] = share
+		if extra > 0 {
+			widths[i]++
+			extra--
+		}
+
+		if widths[i] < minColWidth {
+			widths[i] = minColWidth
+		}
+
+		if widths[i] > natural[i] {
+			widths[i] = natural[i]
+		}
+	}
+
+	return widths
+}
+
+func padding(n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	return strings.Repeat(" ", n)
+}
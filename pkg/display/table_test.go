@@ -0,0 +1,133 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var packages = []map[string]interface{}{
+	{"name": "admiral", "summary": "Create cobra based command line tools", "stars": 10},
+	{"name": "日本語", "summary": "short", "stars": 2},
+}
+
+func TestDisplay_TableDisplayer_Truncate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(packages)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"name", "summary", "stars"})
+	m.EXPECT().NoHeaders().Return(false)
+
+	want := `name       summary                 stars
+admiral    Create cobra based …    10
+日本語     short                   2
+`
+
+	b := bytes.NewBufferString("")
+
+	err := TableDisplayer(b, TableConfig{Width: 40}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_TableDisplayer_Wrap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(packages)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"name", "summary", "stars"})
+	m.EXPECT().NoHeaders().Return(true)
+
+	want := `admiral    Create cobra based         10
+           command line tools
+日本語     short                      2
+`
+
+	b := bytes.NewBufferString("")
+
+	err := TableDisplayer(b, TableConfig{
+		Width:    40,
+		Overflow: map[string]Overflow{"summary": OverflowWrap},
+	}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_TableDisplayer_Wide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(packages)
+	m.EXPECT().Cols().AnyTimes().Return([]string{"name", "summary"})
+	m.EXPECT().NoHeaders().Return(true)
+
+	want := `admiral    Create cobra based command line tools
+日本語     short
+`
+
+	b := bytes.NewBufferString("")
+
+	err := TableDisplayer(b, TableConfig{Width: 20, Wide: true}).Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestFitWidths(t *testing.T) {
+	cases := []struct {
+		name    string
+		natural []int
+		width   int
+		want    []int
+	}{
+		{"fits", []int{5, 10}, 40, []int{5, 10}},
+		{"widest column shrinks", []int{5, 40, 3}, 40, []int{5, 24, 3}},
+		{"wide columns share the space", []int{30, 30}, 44, []int{20, 20}},
+		{"minimum width", []int{30, 30}, 10, []int{5, 5}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, fitWidths(c.natural, c.width))
+		})
+	}
+}
+
+func TestDisplay_TableDisplayer_MultiLine(t *testing.T) {
+	cases := []struct {
+		name string
+		d    Displayable
+		want string
+	}{
+		{
+			name: "pretty json",
+			d:    JSON(map[string]interface{}{"name": "admiral", "stars": 10}, true),
+			want: "{\n    \"name\": \"admiral\",\n    \"stars\": 10\n}\n",
+		},
+		{
+			name: "text",
+			d:    Text("", "first line\nsecond line"),
+			want: "first line\nsecond line\n",
+		},
+	}
+
+	for _, c := range cases {
+		b := bytes.NewBufferString("")
+
+		err := TableDisplayer(b, TableConfig{Width: 80}).Display(c.d, nil)
+
+		assert.Nil(t, err, c.name)
+		assert.Equal(t, c.want, b.String(), c.name)
+	}
+}
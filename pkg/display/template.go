@@ -0,0 +1,157 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"text/template"
+)
+
+// Template based output formats.
+const (
+	GoTemplateFormat = "go-template"
+	JSONPathFormat   = "jsonpath"
+)
+
+func init() {
+	RegisterFormat(GoTemplateFormat, func(output io.Writer, arg string) (Displayer, error) {
+		return GoTemplateDisplayer(output, arg)
+	})
+	RegisterFormat(JSONPathFormat, func(output io.Writer, arg string) (Displayer, error) {
+		return JSONPathDisplayer(output, arg)
+	})
+}
+
+type templateDisplayer struct {
+	output  io.Writer
+	generic bool
+	execute func(w io.Writer, data interface{}) error
+}
+
+// Display evaluates the template once per row returned by KV,
+// or once against the raw object of displayables created with
+// JSON, writing a line break after every evaluation.
+func (td *templateDisplayer) Display(d Displayable, f []string) error {
+	roots, err := templateRoots(d, td.generic)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	for _, root := range roots {
+		buf.Reset()
+
+		if err := td.execute(&buf, root); err != nil {
+			return err
+		}
+
+		buf.WriteByte('\n')
+
+		if _, err := buf.WriteTo(td.output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (td *templateDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(td, ds, f)
+}
+
+func (td *templateDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *td
+	t.output = wrap(td.output)
+
+	return &t
+}
+
+// GoTemplateDisplayer evaluates a text/template against each
+// row of a displayable, writing the result to the provided
+// writer (defaults to os.Stdout).
+//
+// Rows are passed to the template as they are returned by KV,
+// so columns are accessed by name, e.g. {{.Symbol}}.
+func GoTemplateDisplayer(output io.Writer, text string) (Displayer, error) {
+	tpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateDisplayer{
+		output:  stdout(output),
+		execute: tpl.Execute,
+	}, nil
+}
+
+// JSONPathDisplayer evaluates a JSONPath template, in the style
+// used by kubectl, against each row of a displayable writing the
+// result to the provided writer (defaults to os.Stdout).
+//
+// Rows and raw objects are evaluated using their JSON
+// representation, e.g. {.Symbol} or {range .items[*]}{.name}{end}.
+func JSONPathDisplayer(output io.Writer, text string) (Displayer, error) {
+	jp, err := parseJSONPath(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateDisplayer{
+		output:  stdout(output),
+		generic: true,
+		execute: jp.execute,
+	}, nil
+}
+
+func templateRoots(d Displayable, generic bool) ([]interface{}, error) {
+	if raw, ok := rawJSON(d); ok {
+		v, err := toGeneric(raw.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		return []interface{}{v}, nil
+	}
+
+	kv := d.KV()
+	roots := make([]interface{}, 0, len(kv))
+
+	for _, r := range kv {
+		var root interface{} = r
+
+		if generic {
+			v, err := toGeneric(r)
+			if err != nil {
+				return nil, err
+			}
+
+			root = v
+		}
+
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
+
+// toGeneric converts a value into the maps, slices and scalars
+// produced by decoding its JSON representation.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
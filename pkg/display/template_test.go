@@ -0,0 +1,115 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_GoTemplateDisplayer_Rows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+
+	b := bytes.NewBufferString("")
+
+	dsp, err := NewDisplayer("go-template={{.Symbol}}={{.Quote}}", b)
+	assert.Nil(t, err)
+
+	err = dsp.Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "EUR=1\nUSD=1.22\nMXN=24.45\n", b.String())
+}
+
+func TestDisplay_GoTemplateDisplayer_InvalidTemplate(t *testing.T) {
+	_, err := NewDisplayer("go-template={{.Symbol", nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestDisplay_JSONPathDisplayer_Rows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+
+	b := bytes.NewBufferString("")
+
+	dsp, err := NewDisplayer("jsonpath={.Symbol}", b)
+	assert.Nil(t, err)
+
+	err = dsp.Display(m, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "EUR\nUSD\nMXN\n", b.String())
+}
+
+func TestDisplay_JSONPathDisplayer_RawData(t *testing.T) {
+	data := map[string]interface{}{
+		"kind": "list",
+		"items": []map[string]interface{}{
+			{"name": "a", "size": 1, "tags": []string{"x", "y"}},
+			{"name": "b", "size": 2.5, "tags": []string{}},
+		},
+	}
+
+	cases := []struct {
+		name string
+		tpl  string
+		want string
+	}{
+		{"field", "{.kind}", "list\n"},
+		{"root prefix", "{$.kind}", "list\n"},
+		{"wildcard", "{.items[*].name}", "a b\n"},
+		{"index", "{.items[0].size}", "1\n"},
+		{"negative index", "{.items[-1].size}", "2.5\n"},
+		{"quoted name", "{.items[1]['name']}", "b\n"},
+		{"composite values", "{.items[0].tags}", "[\"x\",\"y\"]\n"},
+		{"missing field", "{.nope}", "\n"},
+		{"text and literals", "kind: {.kind}{\"\\t\"}!", "kind: list\t!\n"},
+		{"range", "{range .items[*]}{.name}={.size};{end}", "a=1;b=2.5;\n"},
+		{"nested range", "{range .items[*]}{range .tags[*]}{.}{end}{end}", "xy\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+
+			dsp, err := JSONPathDisplayer(b, c.tpl)
+			assert.Nil(t, err)
+
+			err = dsp.Display(JSON(data, false), nil)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, b.String())
+		})
+	}
+}
+
+func TestDisplay_JSONPathDisplayer_InvalidTemplate(t *testing.T) {
+	cases := []string{
+		"{.kind",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		"{..name}",
+		"{.items[x]}",
+		"{\"unterminated}",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			_, err := JSONPathDisplayer(nil, c)
+
+			assert.True(t, errors.Is(err, ErrFormatArgument))
+		})
+	}
+}
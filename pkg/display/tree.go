@@ -0,0 +1,276 @@
+package display
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// TreeFormat renders nested displayables as a tree.
+const TreeFormat = "tree"
+
+func init() {
+	RegisterFormat(TreeFormat, treeFormat)
+}
+
+// TreeChars holds the prefixes drawn before every node.
+type TreeChars struct {
+	Branch   string
+	Last     string
+	Vertical string
+	Space    string
+}
+
+// Tree drawing sets.
+var (
+	// UnicodeTree draws the tree with box characters like tree(1).
+	UnicodeTree = TreeChars{
+		Branch:   "├── ",
+		Last:     "└── ",
+		Vertical: "│   ",
+		Space:    "    ",
+	}
+	// ASCIITree draws the tree for terminals without unicode support.
+	ASCIITree = TreeChars{
+		Branch:   "|-- ",
+		Last:     "`-- ",
+		Vertical: "|   ",
+		Space:    "    ",
+	}
+)
+
+// DefaultTreeChars returns UnicodeTree unless the locale set on
+// the environment does not use the UTF-8 encoding.
+func DefaultTreeChars() TreeChars {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+
+		v = strings.ToLower(v)
+		if strings.Contains(v, "utf-8") || strings.Contains(v, "utf8") {
+			return UnicodeTree
+		}
+
+		return ASCIITree
+	}
+
+	return UnicodeTree
+}
+
+// TreeConfig customizes the tree output.
+type TreeConfig struct {
+	// Chars holds the drawing set, DefaultTreeChars is used when empty.
+	Chars TreeChars
+	// Exact displays exact values instead of humanized ones.
+	Exact bool
+}
+
+type treeDisplayer struct {
+	output io.Writer
+	chars  TreeChars
+	exact  bool
+}
+
+// Display writes a node per row labeled with the values of the
+// selected columns, followed by the rows of its children.
+//
+// Values holding a Displayable or a []Displayable are the children
+// of the row, when a row holds more than one of them each group
+// is placed below a node named after its column.
+func (td *treeDisplayer) Display(d Displayable, f []string) error {
+	w := bufio.NewWriter(td.output)
+
+	rows := d.KV()
+	cols := getCols(d, f)
+
+	for _, r := range rows {
+		_, _ = w.WriteString(treeLabel(r, cols, humanized(td.exact)) + "\n")
+		td.writeChildren(w, "", treeChildren(d, r))
+	}
+
+	return w.Flush()
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (td *treeDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(td, ds, f)
+}
+
+func (td *treeDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *td
+	t.output = wrap(td.output)
+
+	return &t
+}
+
+func (td *treeDisplayer) exactCopy() Displayer {
+	t := *td
+	t.exact = true
+
+	return &t
+}
+
+type treeNode struct {
+	label    string
+	row      map[string]interface{}
+	parent   Displayable
+	children []treeNode
+}
+
+func (td *treeDisplayer) writeChildren(w *bufio.Writer, prefix string, nodes []treeNode) {
+	for i, n := range nodes {
+		branch, next := td.chars.Branch, td.chars.Vertical
+		if i == len(nodes)-1 {
+			branch, next = td.chars.Last, td.chars.Space
+		}
+
+		label, children := n.label, n.children
+		if n.row != nil {
+			label = treeLabel(n.row, n.parent.Cols(), humanized(td.exact))
+			children = treeChildren(n.parent, n.row)
+		}
+
+		// continuation lines of multiline labels stay below the node.
+		label = strings.ReplaceAll(label, "\n", "\n"+prefix+next)
+
+		_, _ = w.WriteString(prefix + branch + label + "\n")
+		td.writeChildren(w, prefix+next, children)
+	}
+}
+
+// treeChildren returns the nodes nested below a row.
+func treeChildren(d Displayable, r map[string]interface{}) []treeNode {
+	var groups []treeNode
+
+	for _, col := range rowCols(d, r) {
+		children, ok := nestedDisplayables(r[col])
+		if !ok {
+			continue
+		}
+
+		group := treeNode{label: col}
+
+		for _, c := range children {
+			for _, cr := range c.KV() {
+				group.children = append(group.children, treeNode{row: cr, parent: c})
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	if len(groups) == 1 {
+		return groups[0].children
+	}
+
+	return groups
+}
+
+// rowCols returns the available columns of the displayable
+// followed by any other key of the row sorted alphabetically.
+func rowCols(d Displayable, r map[string]interface{}) []string {
+	cols := availableCols(d)
+
+	seen := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		seen[c] = true
+	}
+
+	var extra []string
+
+	for c := range r {
+		if !seen[c] {
+			extra = append(extra, c)
+		}
+	}
+
+	sort.Strings(extra)
+
+	return append(cols, extra...)
+}
+
+// treeLabel joins the values of the columns that are not children.
+func treeLabel(r map[string]interface{}, cols []string, human bool) string {
+	values := make([]string, 0, len(cols))
+
+	for _, col := range cols {
+		if _, ok := nestedDisplayables(r[col]); ok {
+			continue
+		}
+
+		values = append(values, formatValue(col, r[col], human))
+	}
+
+	return strings.Join(values, "  ")
+}
+
+// nestedRows converts the displayables held by a value into
+// rows keeping their own columns, so JSON and YAML outputs
+// preserve the nesting. Other values are returned unchanged.
+func nestedRows(v interface{}) interface{} {
+	children, ok := nestedDisplayables(v)
+	if !ok {
+		return v
+	}
+
+	rows := []orderedRow{}
+
+	for _, c := range children {
+		rows = append(rows, selectRows(c, c.Cols())...)
+	}
+
+	return rows
+}
+
+// nestedDisplayables reports the displayables held by a value.
+func nestedDisplayables(v interface{}) ([]Displayable, bool) {
+	switch t := v.(type) {
+	case Displayable:
+		return []Displayable{t}, true
+	case []Displayable:
+		return t, true
+	default:
+		return nil, false
+	}
+}
+
+// TreeDisplayer renders nested displayables as a tree to the
+// provided writer (defaults to os.Stdout).
+//
+// Rows of the displayable are the roots of the tree, their
+// children are drawn below them like tree(1) does.
+func TreeDisplayer(output io.Writer, config TreeConfig) Displayer {
+	if config.Chars == (TreeChars{}) {
+		config.Chars = DefaultTreeChars()
+	}
+
+	return &treeDisplayer{
+		output: stdout(output),
+		chars:  config.Chars,
+		exact:  config.Exact,
+	}
+}
+
+// treeFormat accepts tree=ascii and tree=unicode to override
+// the drawing set.
+func treeFormat(output io.Writer, arg string) (Displayer, error) {
+	var config TreeConfig
+
+	switch strings.ToLower(arg) {
+	case "":
+	case "ascii":
+		config.Chars = ASCIITree
+	case "unicode":
+		config.Chars = UnicodeTree
+	default:
+		return nil, fmt.Errorf("%w %q, possible values are ascii,unicode", ErrFormatArgument, arg)
+	}
+
+	return TreeDisplayer(output, config), nil
+}
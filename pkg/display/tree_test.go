@@ -0,0 +1,135 @@
+package display
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func projectTree() Displayable {
+	tasks := &staticDisplayable{
+		cols: []string{"task"},
+		rows: []map[string]interface{}{
+			{"task": "design"},
+			{"task": "build", "steps": &staticDisplayable{
+				cols: []string{"step"},
+				rows: []map[string]interface{}{{"step": "compile"}, {"step": "link"}},
+			}},
+		},
+	}
+
+	return &staticDisplayable{
+		cols: []string{"name", "owner"},
+		rows: []map[string]interface{}{
+			{"name": "admiral", "owner": "avocatl", "tasks": tasks},
+			{"name": "docs", "owner": "team", "tasks": []Displayable{}, "links": []Displayable{
+				&staticDisplayable{cols: []string{"url"}, rows: []map[string]interface{}{{"url": "a.md"}}},
+			}},
+		},
+	}
+}
+
+func TestDisplay_TreeDisplayer_Unicode(t *testing.T) {
+	want := "admiral  avocatl\n" +
+		"├── design\n" +
+		"└── build\n" +
+		"    ├── compile\n" +
+		"    └── link\n" +
+		"docs  team\n" +
+		"├── links\n" +
+		"│   └── a.md\n" +
+		"└── tasks\n"
+
+	b := bytes.NewBufferString("")
+
+	err := TreeDisplayer(b, TreeConfig{Chars: UnicodeTree}).Display(projectTree(), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_TreeDisplayer_ASCIIFormat(t *testing.T) {
+	want := "admiral\n" +
+		"|-- design\n" +
+		"`-- build\n" +
+		"    |-- compile\n" +
+		"    `-- link\n" +
+		"docs\n" +
+		"|-- links\n" +
+		"|   `-- a.md\n" +
+		"`-- tasks\n"
+
+	b := bytes.NewBufferString("")
+
+	dsp, err := NewDisplayer("tree=ascii", b)
+	assert.Nil(t, err)
+
+	err = dsp.Display(projectTree(), []string{"name"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+
+	_, err = NewDisplayer("tree=emoji", b)
+	assert.ErrorIs(t, err, ErrFormatArgument)
+}
+
+func TestDefaultTreeChars(t *testing.T) {
+	setenv(t, "LC_ALL", "")
+	setenv(t, "LC_CTYPE", "")
+
+	setenv(t, "LANG", "en_US.UTF-8")
+	assert.Equal(t, UnicodeTree, DefaultTreeChars())
+
+	setenv(t, "LANG", "C")
+	assert.Equal(t, ASCIITree, DefaultTreeChars())
+
+	setenv(t, "LANG", "")
+	assert.Equal(t, UnicodeTree, DefaultTreeChars())
+}
+
+// setenv sets an environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	prev, ok := os.LookupEnv(key)
+
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+
+	os.Setenv(key, value)
+}
+
+func TestDisplay_NestedJSONAndYAML(t *testing.T) {
+	d := projectTree()
+
+	b := bytes.NewBufferString("")
+	err := JSONLDisplayer(b).Display(d, []string{"name", "tasks"})
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		`{"name":"admiral","tasks":[{"task":"design"},{"task":"build"}]}`+"\n"+
+			`{"name":"docs","tasks":[]}`+"\n",
+		b.String(),
+	)
+
+	b.Reset()
+	err = YAMLDisplayer(b).Display(d, []string{"name", "tasks"})
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		"- name: admiral\n"+
+			"  tasks:\n"+
+			"    - task: design\n"+
+			"    - task: build\n"+
+			"- name: docs\n"+
+			"  tasks: []\n",
+		b.String(),
+	)
+}
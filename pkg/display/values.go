@@ -0,0 +1,387 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValueFormatter converts a value into the text displayed
+// for it on human readable outputs, humanize reports whether
+// humanized values were requested or exact ones.
+type ValueFormatter func(v interface{}, humanize bool) string
+
+var (
+	formattersMu sync.RWMutex
+	humanize     = true
+	typeFormats  = map[reflect.Type]ValueFormatter{}
+	colFormats   = map[string]ValueFormatter{}
+
+	// now is replaced on tests to get stable relative times.
+	now = time.Now
+)
+
+// RegisterTypeFormatter sets the formatter used for every value
+// with the same type as the sample, e.g. time.Time{} or
+// float64(0). A nil sample sets how nil values are rendered.
+//
+// Registering a nil formatter restores the default one.
+func RegisterTypeFormatter(sample interface{}, fn ValueFormatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	t := reflect.TypeOf(sample)
+
+	if fn == nil {
+		delete(typeFormats, t)
+
+		return
+	}
+
+	typeFormats[t] = fn
+}
+
+// RegisterColumnFormatter sets the formatter used for the values
+// of a column, it takes precedence over type formatters.
+//
+// Registering a nil formatter restores the default one.
+func RegisterColumnFormatter(col string, fn ValueFormatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	if fn == nil {
+		delete(colFormats, col)
+
+		return
+	}
+
+	colFormats[col] = fn
+}
+
+// SetHumanize enables or disables the humanized defaults, e.g.
+// "3 minutes ago" for times, for the whole process. It is enabled
+// by default, displayers configured as Exact, or built through
+// ExactDisplayer, never humanize their values.
+func SetHumanize(enabled bool) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	humanize = enabled
+}
+
+// Humanize reports whether the humanized defaults are enabled.
+func Humanize() bool {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	return humanize
+}
+
+// FormatValue returns the text displayed for a value of the
+// provided column (empty when unknown) on human readable
+// outputs such as the table.
+//
+// Column formatters are used first, then type formatters and
+// finally the defaults:
+//
+//   - float64 values are written with the decimals they need,
+//     or 6 decimals.
+//   - times are relative, e.g. "3 minutes ago", or RFC 3339.
+//   - durations are rounded to two units, e.g. "1h5m".
+//   - nil values are empty, or <nil>.
+//   - slices and maps list their values separated by commas,
+//     or use the go syntax.
+//
+// The alternatives are used when humanizing is disabled.
+func FormatValue(col string, v interface{}) string {
+	return formatValue(col, v, Humanize())
+}
+
+// exacter is implemented by the displayers humanizing values.
+type exacter interface {
+	exactCopy() Displayer
+}
+
+// ExactDisplayer returns a copy of the displayer rendering exact
+// values instead of humanized ones, e.g. timestamps instead of
+// relative times. It is meant for displayers built by format,
+// those that do not humanize values are returned unchanged.
+func ExactDisplayer(dsp Displayer) Displayer {
+	if e, ok := dsp.(exacter); ok {
+		return e.exactCopy()
+	}
+
+	return dsp
+}
+
+// humanized reports whether a displayer humanizes its values.
+func humanized(exact bool) bool {
+	return !exact && Humanize()
+}
+
+func formatValue(col string, v interface{}, human bool) string {
+	if c, ok := v.(CellChange); ok {
+		return formatValue(col, c.Before, human) + " → " + formatValue(col, c.After, human)
+	}
+
+	formattersMu.RLock()
+	fn, ok := colFormats[col]
+
+	if !ok {
+		fn, ok = typeFormats[reflect.TypeOf(v)]
+	}
+
+	formattersMu.RUnlock()
+
+	if ok {
+		return fn(v, human)
+	}
+
+	return defaultValue(v, human)
+}
+
+func defaultValue(v interface{}, human bool) string {
+	switch t := v.(type) {
+	case nil:
+		if human {
+			return ""
+		}
+
+		return "<nil>"
+	case string:
+		return t
+	case float64:
+		if !human {
+			return fmt.Sprintf("%f", t)
+		}
+
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case time.Time:
+		if !human {
+			return t.Format(time.RFC3339)
+		}
+
+		return relativeTime(t, now())
+	case *time.Time:
+		if t == nil {
+			return defaultValue(nil, human)
+		}
+
+		return defaultValue(*t, human)
+	case time.Duration:
+		if !human {
+			return t.String()
+		}
+
+		return roundDuration(t)
+	case fmt.Stringer, error:
+		return fmt.Sprint(t)
+	}
+
+	if human {
+		if s, ok := joinValues(v); ok {
+			return s
+		}
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// joinValues lists the values of slices and the sorted key
+// value pairs of maps separated by commas, empty collections
+// are left to the go syntax.
+func joinValues(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if rv.Len() == 0 {
+			return "", false
+		}
+	}
+
+	var values []string
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return "", false
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, formatValue("", rv.Index(i).Interface(), true))
+		}
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			values = append(values, fmt.Sprint(k.Interface())+"="+formatValue("", rv.MapIndex(k).Interface(), true))
+		}
+
+		sort.Strings(values)
+	default:
+		return "", false
+	}
+
+	return strings.Join(values, ", "), true
+}
+
+var timeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// relativeTime describes the time relative to now, e.g.
+// "3 minutes ago" or "in 2 days".
+func relativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	d := now.Sub(t)
+
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	if d < time.Second {
+		return "now"
+	}
+
+	var text string
+
+	for _, u := range timeUnits {
+		if d < u.size {
+			continue
+		}
+
+		n := int64(d / u.size)
+		text = strconv.FormatInt(n, 10) + " " + u.name
+
+		if n != 1 {
+			text += "s"
+		}
+
+		break
+	}
+
+	if future {
+		return "in " + text
+	}
+
+	return text + " ago"
+}
+
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// roundDuration writes the two most significant units of the
+// duration, e.g. "2d3h" or "4m12s".
+func roundDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	var b strings.Builder
+
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+
+	written := 0
+
+	for _, u := range durationUnits {
+		if written == 2 || (written == 1 && d < u.size) {
+			break
+		}
+
+		if d < u.size {
+			continue
+		}
+
+		n := d / u.size
+		d -= n * u.size
+
+		b.WriteString(strconv.FormatInt(int64(n), 10) + u.name)
+
+		written++
+	}
+
+	return b.String()
+}
+
+// FloatFormatter returns a formatter writing numbers with the
+// provided number of decimals, e.g. for RegisterTypeFormatter(
+// float64(0), FloatFormatter(2)).
+func FloatFormatter(precision int) ValueFormatter {
+	return func(v interface{}, human bool) string {
+		if f, ok := numeric(v); ok {
+			return strconv.FormatFloat(f, 'f', precision, 64)
+		}
+
+		return defaultValue(v, human)
+	}
+}
+
+// TimeFormatter returns a formatter writing times with the
+// provided layout instead of relative to now.
+func TimeFormatter(layout string) ValueFormatter {
+	return func(v interface{}, human bool) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Format(layout)
+		case *time.Time:
+			if t != nil {
+				return t.Format(layout)
+			}
+		}
+
+		return defaultValue(v, human)
+	}
+}
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// BytesFormatter formats numbers of bytes using binary units,
+// e.g. "1.5 MiB", when humanizing is enabled. It is meant to be
+// registered for the columns holding sizes.
+func BytesFormatter(v interface{}, human bool) string {
+	f, ok := numeric(v)
+	if !ok || !human {
+		return defaultValue(v, human)
+	}
+
+	i := 0
+	for math.Abs(f) >= 1024 && i < len(byteUnits)-1 {
+		f /= 1024
+		i++
+	}
+
+	if i == 0 {
+		return strconv.FormatFloat(f, 'f', -1, 64) + " " + byteUnits[i]
+	}
+
+	return strconv.FormatFloat(f, 'f', 1, 64) + " " + byteUnits[i]
+}
@@ -0,0 +1,115 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue_Defaults(t *testing.T) {
+	at := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	now = func() time.Time { return at.Add(3*time.Minute + 20*time.Second) }
+	defer func() { now = time.Now }()
+
+	cases := []struct {
+		value interface{}
+		human string
+		plain string
+	}{
+		{"text", "text", "text"},
+		{1.22, "1.22", "1.220000"},
+		{42, "42", "42"},
+		{nil, "", "<nil>"},
+		{at, "3 minutes ago", "2021-05-01T10:00:00Z"},
+		{at.Add(26 * time.Hour), "in 1 day", "2021-05-02T12:00:00Z"},
+		{at.Add(3 * time.Minute), "20 seconds ago", "2021-05-01T10:03:00Z"},
+		{time.Time{}, "", "0001-01-01T00:00:00Z"},
+		{(*time.Time)(nil), "", "<nil>"},
+		{time.Hour + 5*time.Minute + 30*time.Second, "1h5m", "1h5m30s"},
+		{1500 * time.Microsecond, "1ms500µs", "1.5ms"},
+		{[]string{"a", "b"}, "a, b", "[a b]"},
+		{map[string]int{"b": 2, "a": 1}, "a=1, b=2", "map[a:1 b:2]"},
+		{[]int{}, "[]", "[]"},
+		{errors.New("failed"), "failed", "failed"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.human, FormatValue("", c.value))
+	}
+
+	SetHumanize(false)
+	defer SetHumanize(true)
+
+	for _, c := range cases {
+		assert.Equal(t, c.plain, FormatValue("", c.value))
+	}
+}
+
+func TestFormatValue_Registered(t *testing.T) {
+	RegisterTypeFormatter(float64(0), FloatFormatter(2))
+	RegisterTypeFormatter(nil, func(interface{}, bool) string { return "-" })
+	RegisterTypeFormatter(time.Time{}, TimeFormatter("2006-01-02"))
+	RegisterColumnFormatter("size", BytesFormatter)
+	RegisterColumnFormatter("ratio", FloatFormatter(0))
+
+	defer func() {
+		RegisterTypeFormatter(float64(0), nil)
+		RegisterTypeFormatter(nil, nil)
+		RegisterTypeFormatter(time.Time{}, nil)
+		RegisterColumnFormatter("size", nil)
+		RegisterColumnFormatter("ratio", nil)
+	}()
+
+	assert.Equal(t, "1.22", FormatValue("quote", 1.2222))
+	assert.Equal(t, "1", FormatValue("ratio", 1.2222))
+	assert.Equal(t, "-", FormatValue("quote", nil))
+	assert.Equal(t, "2021-05-01", FormatValue("at", time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "512 B", FormatValue("size", 512))
+	assert.Equal(t, "1.5 MiB", FormatValue("size", int64(1572864)))
+
+	SetHumanize(false)
+	defer SetHumanize(true)
+
+	assert.Equal(t, "1572864", FormatValue("size", int64(1572864)))
+}
+
+func TestExactDisplayer(t *testing.T) {
+	d := &staticDisplayable{
+		rows: []map[string]interface{}{{"took": 90 * time.Minute, "ratio": 0.5}},
+		cols: []string{"took", "ratio"},
+	}
+
+	cases := []struct {
+		format string
+		human  string
+		exact  string
+	}{
+		{"table", "took     ratio\n1h30m    0.5\n", "took       ratio\n1h30m0s    0.500000\n"},
+		{"describe", "took:   1h30m\nratio:  0.5\n", "took:   1h30m0s\nratio:  0.500000\n"},
+		{"tree", "1h30m  0.5\n", "1h30m0s  0.500000\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+
+			dsp, err := NewDisplayer(c.format, b)
+			assert.Nil(t, err)
+
+			assert.Nil(t, ExactDisplayer(dsp).Display(d, nil))
+			assert.Equal(t, c.exact, b.String())
+
+			b.Reset()
+
+			assert.Nil(t, dsp.Display(d, nil))
+			assert.Equal(t, c.human, b.String())
+		})
+	}
+
+	dsp := JSONDisplayer(nil)
+	assert.Equal(t, dsp, ExactDisplayer(dsp))
+}
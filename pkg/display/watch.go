@@ -0,0 +1,183 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the time between refreshes when
+// none is configured.
+const DefaultWatchInterval = 2 * time.Second
+
+// Terminal control sequences used to redraw the output.
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	cursorHome  = "\x1b[H"
+	clearLine   = "\x1b[K"
+	clearBelow  = "\x1b[J"
+)
+
+// Producer retrieves the data to be displayed.
+type Producer func() (Displayable, error)
+
+// WatchConfig defines the behavior of Watch.
+type WatchConfig struct {
+	// Interval between refreshes, defaults to DefaultWatchInterval.
+	Interval time.Duration
+	// Title is displayed along with the refresh time above
+	// the table.
+	Title string
+	// Fields selected to be displayed.
+	Fields []string
+	// DiffOnly redraws only the lines that changed instead
+	// of clearing the screen on every refresh.
+	DiffOnly bool
+	// Table configures the table appearance, the width
+	// defaults to the terminal width.
+	Table TableConfig
+	// Now returns the refresh time, defaults to time.Now.
+	Now func() time.Time
+}
+
+type watcher struct {
+	output  io.Writer
+	produce Producer
+	config  WatchConfig
+	table   *stdDisplayer
+	cells   [][]string
+	lines   []string
+}
+
+// Watch renders the displayable returned by the producer and
+// redraws it in place every interval until the context is done,
+// highlighting the values that changed since the previous refresh
+// when colors are enabled.
+//
+// Errors returned by the producer are displayed instead of the
+// table and the next refresh is attempted.
+func Watch(ctx context.Context, output io.Writer, produce Producer, config WatchConfig) error {
+	output = stdout(output)
+
+	if config.Interval <= 0 {
+		config.Interval = DefaultWatchInterval
+	}
+
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	if config.Table.Width == 0 {
+		config.Table.Width = TerminalWidth(output)
+	}
+
+	w := &watcher{
+		output:  output,
+		produce: produce,
+		config:  config,
+		table:   TableDisplayer(output, config.Table).(*stdDisplayer),
+	}
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		// nobody is reading the output anymore.
+		if err := w.refresh(); err != nil {
+			return ignoreBrokenPipe(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *watcher) refresh() error {
+	var buf bytes.Buffer
+
+	if w.config.Title != "" {
+		fmt.Fprintf(&buf, "%s    %s\n\n", w.config.Title, w.config.Now().Format(time.RFC1123))
+	}
+
+	if err := w.render(&buf); err != nil {
+		fmt.Fprintf(&buf, "error: %v\n", err)
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	var out strings.Builder
+
+	if !w.config.DiffOnly || w.lines == nil {
+		out.WriteString(clearScreen)
+		out.WriteString(buf.String())
+	} else {
+		out.WriteString(cursorHome)
+
+		for i, l := range lines {
+			if l == "" {
+				continue
+			}
+
+			if i < len(w.lines) && w.lines[i] == l && strings.HasSuffix(l, "\n") {
+				out.WriteString("\n")
+
+				continue
+			}
+
+			out.WriteString(strings.TrimSuffix(l, "\n") + clearLine)
+
+			if strings.HasSuffix(l, "\n") {
+				out.WriteString("\n")
+			}
+		}
+
+		out.WriteString(clearBelow)
+	}
+
+	w.lines = lines
+
+	_, err := io.WriteString(w.output, out.String())
+
+	return err
+}
+
+func (w *watcher) render(buf *bytes.Buffer) error {
+	d, err := w.produce()
+	if err != nil {
+		return err
+	}
+
+	var style StyleFunc
+	if w.table.colored {
+		style = w.table.config.Style
+	}
+
+	cols := getCols(d, w.config.Fields)
+	cells, styles := tableCells(d, cols, style, humanized(w.table.config.Exact))
+
+	// values are compared by position with the previous refresh.
+	if w.table.colored && w.cells != nil {
+		for r := range cells {
+			for c := range cells[r] {
+				if r < len(w.cells) && c < len(w.cells[r]) && w.cells[r][c] == cells[r][c] {
+					continue
+				}
+
+				styles[r][c].Reverse = true
+			}
+		}
+	}
+
+	w.cells = cells
+
+	t := *w.table
+	t.output = buf
+
+	return t.render(cols, cells, styles)
+}
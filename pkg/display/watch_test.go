@@ -0,0 +1,100 @@
+package display
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticDisplayable struct {
+	rows []map[string]interface{}
+	cols []string
+}
+
+func (sd *staticDisplayable) KV() []map[string]interface{} { return sd.rows }
+func (sd *staticDisplayable) Cols() []string               { return sd.cols }
+func (sd *staticDisplayable) ColMap() map[string]string    { return map[string]string{} }
+func (sd *staticDisplayable) NoHeaders() bool              { return false }
+func (sd *staticDisplayable) Filterable() bool             { return true }
+
+// frameWriter collects the frames written by Watch and cancels
+// the context once enough of them were received.
+type frameWriter struct {
+	mu     sync.Mutex
+	frames []string
+	limit  int
+	cancel context.CancelFunc
+}
+
+func (fw *frameWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	fw.frames = append(fw.frames, string(p))
+	if len(fw.frames) == fw.limit {
+		fw.cancel()
+	}
+
+	return len(p), nil
+}
+
+func watchFrames(t *testing.T, limit, failing int, config WatchConfig) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fw := &frameWriter{limit: limit, cancel: cancel}
+
+	calls := 0
+	produce := func() (Displayable, error) {
+		calls++
+		if calls == failing {
+			return nil, errors.New("temporary failure")
+		}
+
+		return &staticDisplayable{
+			cols: []string{"name", "count"},
+			rows: []map[string]interface{}{
+				{"name": "a", "count": 1},
+				{"name": "b", "count": calls},
+			},
+		}, nil
+	}
+
+	config.Interval = time.Millisecond
+	config.Now = func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	err := Watch(ctx, fw, produce, config)
+
+	assert.Nil(t, err)
+	assert.Len(t, fw.frames, limit)
+
+	return fw.frames
+}
+
+func TestWatch_ClearScreen(t *testing.T) {
+	frames := watchFrames(t, 3, 2, WatchConfig{Title: "Every 1ms"})
+
+	assert.Equal(t, clearScreen+"Every 1ms    Fri, 01 Jan 2021 00:00:00 UTC\n\nname    count\na       1\nb       1\n", frames[0])
+	assert.Equal(t, clearScreen+"Every 1ms    Fri, 01 Jan 2021 00:00:00 UTC\n\nerror: temporary failure\n", frames[1])
+	assert.Equal(t, clearScreen+"Every 1ms    Fri, 01 Jan 2021 00:00:00 UTC\n\nname    count\na       1\nb       3\n", frames[2])
+}
+
+func TestWatch_DiffOnlyHighlight(t *testing.T) {
+	frames := watchFrames(t, 3, 0, WatchConfig{
+		DiffOnly: true,
+		Table:    TableConfig{Color: ColorAlways},
+	})
+
+	assert.True(t, strings.HasPrefix(frames[0], clearScreen))
+
+	// only the changed line is redrawn with the changed value highlighted.
+	want := cursorHome + "\n" + "\n" + "b       \x1b[7m3\x1b[0m" + clearLine + "\n" + clearBelow
+	assert.Equal(t, want, frames[2])
+}
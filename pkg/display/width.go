@@ -0,0 +1,191 @@
+package display
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// Ellipsis is appended to truncated values.
+const Ellipsis = "…"
+
+// TerminalWidth returns the number of columns of the terminal
+// attached to the output, or 0 if the output is not a terminal.
+//
+// The COLUMNS environment variable is used when the terminal
+// size can not be queried.
+func TerminalWidth(output io.Writer) int {
+	if !isTerminal(output) {
+		return 0
+	}
+
+	f := output.(*os.File)
+
+	if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+		return w
+	}
+
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+
+	return 0
+}
+
+// ansiLen returns the length of the ANSI escape sequence at the
+// beginning of s, or 0 if s does not start with one.
+func ansiLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+
+	switch s[1] {
+	case '[':
+		// CSI sequences end with a byte in the range @ to ~.
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		// OSC sequences end with BEL or ST (ESC \).
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+
+	return len(s)
+}
+
+// StripANSI removes the ANSI escape sequences from s.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			i += n
+
+			continue
+		}
+
+		b.WriteByte(s[i])
+		i++
+	}
+
+	return b.String()
+}
+
+// StringWidth returns the number of terminal cells needed to
+// display s, East Asian wide characters take two cells and
+// ANSI escape sequences none.
+func StringWidth(s string) int {
+	return runewidth.StringWidth(StripANSI(s))
+}
+
+// Truncate shortens s so it fits in width cells, replacing the
+// removed content with an ellipsis. Escape sequences are kept
+// and reset after the ellipsis.
+func Truncate(s string, width int) string {
+	if StringWidth(s) <= width {
+		return s
+	}
+
+	if width <= 0 {
+		return ""
+	}
+
+	limit := width - runewidth.StringWidth(Ellipsis)
+	head, escaped := cut(s, limit)
+
+	if escaped {
+		return head + Ellipsis + "\x1b[0m"
+	}
+
+	return head + Ellipsis
+}
+
+// Wrap splits s into lines of at most width cells, breaking on
+// spaces when possible and inside words otherwise.
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+
+	var lines []string
+
+	for _, para := range strings.Split(s, "\n") {
+		lines = append(lines, wrapLine(para, width)...)
+	}
+
+	return lines
+}
+
+func wrapLine(s string, width int) []string {
+	var lines []string
+
+	for StringWidth(s) > width {
+		head, _ := cut(s, width)
+		if head == "" {
+			_, size := utf8.DecodeRuneInString(s)
+			head = s[:size]
+		}
+
+		// prefer breaking at the last space of the line.
+		if i := strings.LastIndexByte(head, ' '); i > 0 {
+			head = head[:i]
+		}
+
+		lines = append(lines, strings.TrimRight(head, " "))
+		s = strings.TrimLeft(s[len(head):], " ")
+	}
+
+	if s != "" || len(lines) == 0 {
+		lines = append(lines, s)
+	}
+
+	return lines
+}
+
+// cut returns the longest prefix of s that fits in width cells
+// and whether it contains escape sequences.
+func cut(s string, width int) (string, bool) {
+	used, escaped := 0, false
+
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			i += n
+			escaped = true
+
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		w := runewidth.RuneWidth(r)
+		if used+w > width {
+			return s[:i], escaped
+		}
+
+		used += w
+		i += size
+	}
+
+	return s, escaped
+}
@@ -0,0 +1,61 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringWidth(t *testing.T) {
+	cases := []struct {
+		name  string
+		given string
+		want  int
+	}{
+		{"ascii", "hello", 5},
+		{"accents", "café", 4},
+		{"east asian wide", "日本語", 6},
+		{"ansi color", "\x1b[31mred\x1b[0m", 3},
+		{"osc hyperlink", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", 4},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, StringWidth(c.given))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		name  string
+		given string
+		width int
+		want  string
+	}{
+		{"fits", "hello", 5, "hello"},
+		{"truncated", "hello world", 6, "hello…"},
+		{"wide characters", "日本語テキスト", 7, "日本語…"},
+		{"escape sequences are reset", "\x1b[31mhello world\x1b[0m", 4, "\x1b[31mhel…\x1b[0m"},
+		{"no room", "hello", 0, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, Truncate(c.given, c.width))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"the quick", "brown fox"}, Wrap("the quick brown fox", 10))
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, Wrap("abcdefghij", 4))
+	assert.Equal(t, []string{"日本", "語"}, Wrap("日本語", 4))
+	assert.Equal(t, []string{"a", "b"}, Wrap("a\nb", 4))
+	assert.Equal(t, []string{"日", "本"}, Wrap("日本", 1))
+}
+
+func TestTerminalWidth_NotATerminal(t *testing.T) {
+	assert.Equal(t, 0, TerminalWidth(bytes.NewBufferString("")))
+}
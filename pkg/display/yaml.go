@@ -0,0 +1,142 @@
+package display
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormat renders displayables as a YAML list of mappings.
+const YAMLFormat = "yaml"
+
+func init() {
+	RegisterFormat(YAMLFormat, SimpleFormat(YAMLDisplayer))
+}
+
+type yamlDisplayer struct {
+	output io.Writer
+}
+
+// Display renders the selected columns of every row as
+// a YAML sequence of mappings keeping the column order.
+func (yd *yamlDisplayer) Display(d Displayable, f []string) error {
+	enc := yaml.NewEncoder(yd.output)
+	enc.SetIndent(2)
+
+	doc, err := yamlDocument(d, f)
+	if err != nil {
+		return err
+	}
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// DisplayMany executes the displaying process on multiple
+// displayable structs.
+func (yd *yamlDisplayer) DisplayMany(ds []Displayable, f []string) error {
+	return displayEach(yd, ds, f)
+}
+
+func (yd *yamlDisplayer) wrapOutput(wrap func(io.Writer) io.Writer) Displayer {
+	t := *yd
+	t.output = wrap(yd.output)
+
+	return &t
+}
+
+// YAMLDisplayer renders the rows of a displayable as a YAML
+// list of mappings to the provided writer (defaults to
+// os.Stdout).
+//
+// Values keep the type returned by KV, so numbers and
+// booleans are not quoted.
+func YAMLDisplayer(output io.Writer) Displayer {
+	return &yamlDisplayer{
+		output: stdout(output),
+	}
+}
+
+func yamlDocument(d Displayable, f []string) (*yaml.Node, error) {
+	if raw, ok := rawJSON(d); ok {
+		return yamlFromJSON(raw.Data)
+	}
+
+	cols := getCols(d, f)
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+	for _, r := range d.KV() {
+		m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for _, col := range cols {
+			v, err := yamlValue(r[col])
+			if err != nil {
+				return nil, err
+			}
+
+			m.Content = append(
+				m.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: col},
+				v,
+			)
+		}
+
+		seq.Content = append(seq.Content, m)
+	}
+
+	return seq, nil
+}
+
+// yamlValue encodes a value, nested displayables are rendered
+// as sequences keeping the column order of each child.
+func yamlValue(v interface{}) (*yaml.Node, error) {
+	children, ok := nestedDisplayables(v)
+	if !ok {
+		n := new(yaml.Node)
+
+		return n, n.Encode(v)
+	}
+
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+	for _, c := range children {
+		doc, err := yamlDocument(c, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		seq.Content = append(seq.Content, doc.Content...)
+	}
+
+	return seq, nil
+}
+
+// yamlFromJSON encodes the data through its JSON representation
+// so json struct tags and field order are honored.
+func yamlFromJSON(data interface{}) (*yaml.Node, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	plainStyle(&doc)
+
+	return &doc, nil
+}
+
+func plainStyle(n *yaml.Node) {
+	n.Style = 0
+
+	for _, c := range n.Content {
+		plainStyle(c)
+	}
+}
@@ -0,0 +1,81 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay_YAMLDisplayer_Content(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return(currencies)
+	m.EXPECT().Cols().AnyTimes().Return(currencyCol)
+
+	want := `- Symbol: EUR
+  Quote: 1
+- Symbol: USD
+  Quote: 1.22
+- Symbol: MXN
+  Quote: 24.45
+`
+
+	b := bytes.NewBufferString("")
+
+	err := YAMLDisplayer(b).Display(m, []string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_YAMLDisplayer_FilteredTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockDisplayable(ctrl)
+
+	m.EXPECT().KV().Return([]map[string]interface{}{
+		{
+			"id":      1,
+			"active":  true,
+			"name":    "yes",
+			"created": time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			"deleted": nil,
+		},
+	})
+	m.EXPECT().Cols().AnyTimes().Return([]string{"id", "name"})
+	m.EXPECT().Filterable().Return(true)
+
+	want := `- name: "yes"
+  active: true
+  created: 2021-01-02T03:04:05Z
+  deleted: null
+`
+
+	b := bytes.NewBufferString("")
+
+	err := YAMLDisplayer(b).Display(m, []string{"name", "active", "created", "deleted"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
+
+func TestDisplay_YAMLDisplayer_RawData(t *testing.T) {
+	b := bytes.NewBufferString("")
+
+	data := struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}{"admiral", []string{"cli", "cobra"}}
+
+	err := YAMLDisplayer(b).Display(JSON(data, false), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "name: admiral\ntags:\n  - cli\n  - cobra\n", b.String())
+}